@@ -4,6 +4,7 @@ import (
 	"github.com/jinzhu/gorm"
 
 	postgresConfig "github.com/willy182/boilerplate-go-cleanarch/config/postgres"
+	"github.com/willy182/boilerplate-go-cleanarch/config/storage"
 )
 
 // Config main
@@ -11,6 +12,7 @@ type Config struct {
 	PostgresDB struct {
 		Read, Write *gorm.DB
 	}
+	Storage storage.Storage
 }
 
 var conf *Config
@@ -21,6 +23,7 @@ func Load() *Config {
 		conf = new(Config)
 		conf.PostgresDB.Read = postgresConfig.GetReadDB()
 		conf.PostgresDB.Write = postgresConfig.GetWriteDB()
+		conf.Storage = storage.GetStorage()
 	}
 
 	return conf
@@ -110,7 +110,7 @@ func CreateDBConnection(descriptor string) *gorm.DB {
 	// set database log into file
 	if isDebug {
 		db.LogMode(true)
-		db.SetLogger(gorm.Logger{dbLogger})
+		db.SetLogger(gorm.Logger{LogWriter: dbLogger})
 	}
 
 	return db
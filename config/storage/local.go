@@ -0,0 +1,63 @@
+package storage
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// LocalStorage storage of file in local filesystem
+type LocalStorage struct {
+	// Dir directory where the files are written
+	Dir string
+	// URL public url prefix of Dir
+	URL string
+}
+
+// NewLocalStorage function for initializing local filesystem storage
+func NewLocalStorage(dir, url string) *LocalStorage {
+	return &LocalStorage{Dir: dir, URL: strings.TrimRight(url, "/")}
+}
+
+// Put function for writing body into file of key under storage directory
+func (s *LocalStorage) Put(ctx context.Context, key, contentType string, body []byte) (string, error) {
+	filename, err := s.path(key)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+		return "", err
+	}
+
+	if err := ioutil.WriteFile(filename, body, 0644); err != nil {
+		return "", err
+	}
+
+	return s.URL + "/" + key, nil
+}
+
+// Delete function for removing file of key, missing file is not an error
+func (s *LocalStorage) Delete(ctx context.Context, key string) error {
+	filename, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(filename); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// path function for getting file path of key, key must not escape storage directory
+func (s *LocalStorage) path(key string) (string, error) {
+	cleaned := path.Clean("/" + key)
+	if cleaned == "/" || cleaned[1:] != key {
+		return "", os.ErrInvalid
+	}
+	return filepath.Join(s.Dir, filepath.FromSlash(key)), nil
+}
@@ -0,0 +1,203 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// S3DefaultRegion region used for signing request when it is not configured
+	S3DefaultRegion = "us-east-1"
+	// S3DefaultTimeout timeout of request into object storage
+	S3DefaultTimeout = 30 * time.Second
+
+	s3Algorithm = "AWS4-HMAC-SHA256"
+	s3DateTime  = "20060102T150405Z"
+	s3Date      = "20060102"
+)
+
+// S3Config configuration of S3 compatible object storage
+type S3Config struct {
+	// Endpoint base url of object storage, e.g. https://s3.amazonaws.com or http://localhost:9000
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	// PublicURL url prefix of stored objects, Endpoint/Bucket is used when it is empty
+	PublicURL string
+}
+
+// S3Storage storage of file in S3 compatible object storage using path style request signed by signature version 4
+type S3Storage struct {
+	config     S3Config
+	httpClient *http.Client
+}
+
+// NewS3Storage function for initializing S3 compatible object storage
+func NewS3Storage(config S3Config) *S3Storage {
+	config.Endpoint = strings.TrimRight(config.Endpoint, "/")
+	if config.Region == "" {
+		config.Region = S3DefaultRegion
+	}
+
+	if config.PublicURL == "" {
+		config.PublicURL = config.Endpoint + "/" + config.Bucket
+	}
+	config.PublicURL = strings.TrimRight(config.PublicURL, "/")
+
+	return &S3Storage{
+		config:     config,
+		httpClient: &http.Client{Timeout: S3DefaultTimeout},
+	}
+}
+
+// Put function for uploading body as object of key
+func (s *S3Storage) Put(ctx context.Context, key, contentType string, body []byte) (string, error) {
+	header := http.Header{}
+	header.Set("Content-Type", contentType)
+
+	if err := s.do(ctx, http.MethodPut, key, header, body); err != nil {
+		return "", err
+	}
+
+	return s.config.PublicURL + "/" + escapePath(key), nil
+}
+
+// Delete function for removing object of key
+func (s *S3Storage) Delete(ctx context.Context, key string) error {
+	return s.do(ctx, http.MethodDelete, key, http.Header{}, nil)
+}
+
+// do function for sending signed request of object key
+func (s *S3Storage) do(ctx context.Context, method, key string, header http.Header, body []byte) error {
+	endpoint, err := url.Parse(s.config.Endpoint)
+	if err != nil {
+		return err
+	}
+
+	objectPath := "/" + escapePath(s.config.Bucket) + "/" + escapePath(key)
+	if endpoint.Path != "" {
+		objectPath = strings.TrimRight(endpoint.EscapedPath(), "/") + objectPath
+	}
+
+	req, err := http.NewRequest(method, endpoint.Scheme+"://"+endpoint.Host+objectPath, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.Header = header
+	req.ContentLength = int64(len(body))
+
+	s.sign(req, objectPath, body, time.Now().UTC())
+
+	res, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= http.StatusMultipleChoices {
+		message, _ := ioutil.ReadAll(res.Body)
+		return fmt.Errorf("object storage %s %s: %s %s", method, key, res.Status, strings.TrimSpace(string(message)))
+	}
+
+	return nil
+}
+
+// sign function for adding signature version 4 authorization into request
+func (s *S3Storage) sign(req *http.Request, canonicalURI string, body []byte, now time.Time) {
+	payloadHash := sha256Hex(body)
+	req.Header.Set("X-Amz-Date", now.Format(s3DateTime))
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	// every header below that is set is signed
+	canonicalRequest, signedHeaders := buildCanonicalRequest(req.Method, canonicalURI, map[string]string{
+		"content-type":         req.Header.Get("Content-Type"),
+		"host":                 req.URL.Host,
+		"x-amz-content-sha256": payloadHash,
+		"x-amz-date":           now.Format(s3DateTime),
+	}, payloadHash)
+	signature := signCanonicalRequest(s.config.SecretKey, s.config.Region, now, canonicalRequest)
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3Algorithm, s.config.AccessKey, s3Scope(now, s.config.Region), signedHeaders, signature))
+}
+
+// buildCanonicalRequest function for building canonical request of signature version 4 without query string
+// and its signed headers, header is keyed by lower case name and header with empty value is not signed
+func buildCanonicalRequest(method, canonicalURI string, header map[string]string, payloadHash string) (string, string) {
+	names := make([]string, 0, len(header))
+	for name, value := range header {
+		if value != "" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(header[name]) + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		method,
+		canonicalURI,
+		"",
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	return canonicalRequest, signedHeaders
+}
+
+// signCanonicalRequest function for getting signature version 4 of canonical request at now,
+// using signing key derived from secretKey for the date, region and s3 service
+func signCanonicalRequest(secretKey, region string, now time.Time, canonicalRequest string) string {
+	stringToSign := strings.Join([]string{s3Algorithm, now.Format(s3DateTime), s3Scope(now, region), sha256Hex([]byte(canonicalRequest))}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+secretKey), now.Format(s3Date))
+	signingKey = hmacSHA256(signingKey, region)
+	signingKey = hmacSHA256(signingKey, "s3")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	return hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+}
+
+// s3Scope function for getting credential scope of signature version 4 at now in region
+func s3Scope(now time.Time, region string) string {
+	return now.Format(s3Date) + "/" + region + "/s3/aws4_request"
+}
+
+// escapePath function for escaping each segment of object key as required by signature version 4
+func escapePath(key string) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = strings.Replace(url.QueryEscape(segment), "+", "%20", -1)
+	}
+	return strings.Join(segments, "/")
+}
+
+// sha256Hex function for getting hex encoded sha256 of data
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// hmacSHA256 function for getting hmac sha256 of data using key
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,224 @@
+package storage
+
+import (
+	"context"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// s3Request data of struct for request received by fake object storage
+type s3Request struct {
+	method  string
+	path    string
+	body    string
+	headers http.Header
+	err     string
+}
+
+// newS3Server function for starting fake object storage which verifies signature version 4 of every request
+func newS3Server(secretKey string, status int, received *[]s3Request) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		*received = append(*received, s3Request{
+			method:  r.Method,
+			path:    r.URL.EscapedPath(),
+			body:    string(body),
+			headers: r.Header,
+			err:     verifySignature(r, body, secretKey),
+		})
+		w.WriteHeader(status)
+		w.Write([]byte("<Error>denied</Error>"))
+	}))
+}
+
+// verifySignature function for checking authorization of request the way object storage does,
+// empty string is returned when the signature is valid
+func verifySignature(r *http.Request, body []byte, secretKey string) string {
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, s3Algorithm+" ") {
+		return "authorization algorithm: " + auth
+	}
+
+	fields := map[string]string{}
+	for _, field := range strings.Split(strings.TrimPrefix(auth, s3Algorithm+" "), ", ") {
+		parts := strings.SplitN(field, "=", 2)
+		if len(parts) != 2 {
+			return "authorization field: " + field
+		}
+		fields[parts[0]] = parts[1]
+	}
+
+	credential := strings.SplitN(fields["Credential"], "/", 2)
+	if len(credential) != 2 {
+		return "credential: " + fields["Credential"]
+	}
+	scope := credential[1]
+	scopeParts := strings.Split(scope, "/")
+	if len(scopeParts) != 4 || scopeParts[2] != "s3" || scopeParts[3] != "aws4_request" {
+		return "scope: " + scope
+	}
+
+	payloadHash := sha256Hex(body)
+	if r.Header.Get("X-Amz-Content-Sha256") != payloadHash {
+		return "payload hash: " + r.Header.Get("X-Amz-Content-Sha256")
+	}
+
+	amzDate := r.Header.Get("X-Amz-Date")
+	if !strings.HasPrefix(amzDate, scopeParts[0]) {
+		return "date: " + amzDate
+	}
+
+	var canonicalHeaders strings.Builder
+	for _, name := range strings.Split(fields["SignedHeaders"], ";") {
+		value := r.Header.Get(name)
+		if name == "host" {
+			value = r.Host
+		}
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(value) + "\n")
+	}
+
+	canonicalRequest := strings.Join([]string{
+		r.Method,
+		r.URL.EscapedPath(),
+		r.URL.RawQuery,
+		canonicalHeaders.String(),
+		fields["SignedHeaders"],
+		payloadHash,
+	}, "\n")
+	stringToSign := strings.Join([]string{s3Algorithm, amzDate, scope, sha256Hex([]byte(canonicalRequest))}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+secretKey), scopeParts[0])
+	key = hmacSHA256(key, scopeParts[1])
+	key = hmacSHA256(key, scopeParts[2])
+	key = hmacSHA256(key, scopeParts[3])
+	if signature := hex.EncodeToString(hmacSHA256(key, stringToSign)); signature != fields["Signature"] {
+		return "signature: " + fields["Signature"] + ", expected: " + signature
+	}
+
+	return ""
+}
+
+func TestS3StoragePut(t *testing.T) {
+	var received []s3Request
+	server := newS3Server("secret", http.StatusOK, &received)
+	defer server.Close()
+
+	s3 := NewS3Storage(S3Config{Endpoint: server.URL + "/", Region: "ap-southeast-1", Bucket: "media", AccessKey: "access", SecretKey: "secret"})
+
+	url, err := s3.Put(context.Background(), "articles/1/my image.png", "image/png", []byte("png data"))
+	if err != nil {
+		t.Fatalf("put: %v", err)
+	}
+
+	if expected := server.URL + "/media/articles/1/my%20image.png"; url != expected {
+		t.Errorf("url = %q, expected %q", url, expected)
+	}
+
+	if len(received) != 1 {
+		t.Fatalf("received %d request, expected 1", len(received))
+	}
+
+	req := received[0]
+	if req.method != http.MethodPut || req.path != "/media/articles/1/my%20image.png" || req.body != "png data" {
+		t.Errorf("request = %s %s %q", req.method, req.path, req.body)
+	}
+
+	if req.err != "" {
+		t.Errorf("invalid signature, %s", req.err)
+	}
+
+	if auth := req.headers.Get("Authorization"); !strings.Contains(auth, "Credential=access/") ||
+		!strings.Contains(auth, "/ap-southeast-1/s3/aws4_request") ||
+		!strings.Contains(auth, "SignedHeaders=content-type;host;x-amz-content-sha256;x-amz-date") {
+		t.Errorf("authorization = %q", auth)
+	}
+}
+
+func TestS3StorageDelete(t *testing.T) {
+	var received []s3Request
+	server := newS3Server("secret", http.StatusNoContent, &received)
+	defer server.Close()
+
+	s3 := NewS3Storage(S3Config{Endpoint: server.URL + "/prefix", Bucket: "media", AccessKey: "access", SecretKey: "secret"})
+
+	if err := s3.Delete(context.Background(), "articles/1/a+b.png"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+
+	req := received[0]
+	if req.method != http.MethodDelete || req.path != "/prefix/media/articles/1/a%2Bb.png" || req.body != "" {
+		t.Errorf("request = %s %s %q", req.method, req.path, req.body)
+	}
+
+	if req.err != "" {
+		t.Errorf("invalid signature, %s", req.err)
+	}
+
+	if auth := req.headers.Get("Authorization"); !strings.Contains(auth, "/"+S3DefaultRegion+"/s3/") ||
+		!strings.Contains(auth, "SignedHeaders=host;x-amz-content-sha256;x-amz-date") {
+		t.Errorf("authorization = %q", auth)
+	}
+}
+
+func TestS3StorageSignatureMismatch(t *testing.T) {
+	var received []s3Request
+	server := newS3Server("other secret", http.StatusOK, &received)
+	defer server.Close()
+
+	s3 := NewS3Storage(S3Config{Endpoint: server.URL, Bucket: "media", AccessKey: "access", SecretKey: "secret"})
+	if _, err := s3.Put(context.Background(), "key", "text/plain", []byte("data")); err != nil {
+		t.Fatalf("put: %v", err)
+	}
+
+	if !strings.HasPrefix(received[0].err, "signature: ") {
+		t.Errorf("signed with wrong secret is accepted, %q", received[0].err)
+	}
+}
+
+func TestS3StorageError(t *testing.T) {
+	var received []s3Request
+	server := newS3Server("secret", http.StatusForbidden, &received)
+	defer server.Close()
+
+	s3 := NewS3Storage(S3Config{Endpoint: server.URL, Bucket: "media", AccessKey: "access", SecretKey: "secret"})
+
+	_, err := s3.Put(context.Background(), "key", "text/plain", []byte("data"))
+	if err == nil || !strings.Contains(err.Error(), "403") || !strings.Contains(err.Error(), "<Error>denied</Error>") {
+		t.Errorf("error = %v, expected 403 with response body", err)
+	}
+}
+
+// TestS3SignatureDocumentationVector signs the GET object example of the signature version 4 documentation of Amazon S3
+func TestS3SignatureDocumentationVector(t *testing.T) {
+	const (
+		secretKey         = "wJalrXUtnFEMI/K7MDENG/bPxRfiCYEXAMPLEKEY"
+		emptyPayloadHash  = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+		expectedSignature = "f0e8bdb87c964420e857bd35b5d6ed310bd44f0170aba48dd91039c6036bdb41"
+	)
+	now := time.Date(2013, 5, 24, 0, 0, 0, 0, time.UTC)
+
+	canonicalRequest, signedHeaders := buildCanonicalRequest(http.MethodGet, "/test.txt", map[string]string{
+		"host":                 "examplebucket.s3.amazonaws.com",
+		"range":                "bytes=0-9",
+		"x-amz-content-sha256": emptyPayloadHash,
+		"x-amz-date":           "20130524T000000Z",
+		"content-type":         "",
+	}, emptyPayloadHash)
+
+	if expected := "host;range;x-amz-content-sha256;x-amz-date"; signedHeaders != expected {
+		t.Errorf("signed headers = %q, expected %q", signedHeaders, expected)
+	}
+
+	if hash, expected := sha256Hex([]byte(canonicalRequest)), "7344ae5b7ee6c3e7e6b0fe0640412a37625d1fbfff95c48bbb2dc43964946972"; hash != expected {
+		t.Errorf("canonical request hash = %s, expected %s, canonical request:\n%s", hash, expected, canonicalRequest)
+	}
+
+	if signature := signCanonicalRequest(secretKey, "us-east-1", now, canonicalRequest); signature != expectedSignature {
+		t.Errorf("signature = %s, expected %s", signature, expectedSignature)
+	}
+}
@@ -0,0 +1,57 @@
+package storage
+
+import (
+	"context"
+	"os"
+)
+
+const (
+	// DriverLocal storage driver for storing file in local filesystem
+	DriverLocal = "local"
+	// DriverS3 storage driver for storing file in S3 compatible object storage
+	DriverS3 = "s3"
+
+	// LocalDefaultDir default directory of local storage
+	LocalDefaultDir = "uploads"
+	// LocalDefaultURL default public url of local storage, it is served by the http server
+	LocalDefaultURL = "/uploads"
+)
+
+// Storage interface for storing uploaded file
+type Storage interface {
+	// Put stores body under key and returns its public url
+	Put(ctx context.Context, key, contentType string, body []byte) (string, error)
+	// Delete removes file stored under key
+	Delete(ctx context.Context, key string) error
+}
+
+var store Storage
+
+// GetStorage function to get storage of STORAGE_DRIVER environment, local storage is used by default
+func GetStorage() Storage {
+	if store == nil {
+		switch os.Getenv("STORAGE_DRIVER") {
+		case DriverS3:
+			store = NewS3Storage(S3Config{
+				Endpoint:  os.Getenv("S3_ENDPOINT"),
+				Region:    os.Getenv("S3_REGION"),
+				Bucket:    os.Getenv("S3_BUCKET"),
+				AccessKey: os.Getenv("S3_ACCESS_KEY"),
+				SecretKey: os.Getenv("S3_SECRET_KEY"),
+				PublicURL: os.Getenv("S3_PUBLIC_URL"),
+			})
+		default:
+			store = NewLocalStorage(getEnv("STORAGE_LOCAL_DIR", LocalDefaultDir), getEnv("STORAGE_LOCAL_URL", LocalDefaultURL))
+		}
+	}
+
+	return store
+}
+
+// getEnv function to get environment value or its fallback when it is empty
+func getEnv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/willy182/boilerplate-go-cleanarch/utils"
 
 	"github.com/joho/godotenv"
+	log "github.com/sirupsen/logrus"
 )
 
 func main() {
@@ -27,6 +28,14 @@ func main() {
 
 	service := InitHSIService(config.Load())
 
+	// sitemap command writes sitemap into disk and exits without serving
+	if len(os.Args) > 1 && os.Args[1] == SitemapCommand {
+		if err := service.RunSitemapCommand(os.Args[2:]); err != nil {
+			utils.Log(log.FatalLevel, err.Error(), "main()", "sitemap_command")
+		}
+		return
+	}
+
 	signals := make(chan os.Signal, 1)
 	signal.Notify(signals, os.Interrupt)
 	signal.Notify(signals, os.Kill)
@@ -42,6 +51,18 @@ func main() {
 		wg.Done()
 	}()
 
+	wg.Add(1)
+	go func() {
+		service.Schedule()
+		wg.Done()
+	}()
+
+	wg.Add(1)
+	go func() {
+		service.CountViews()
+		wg.Done()
+	}()
+
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
@@ -49,6 +70,8 @@ func main() {
 			select {
 			case s := <-signals:
 				fmt.Println(s.String())
+				// views still in memory would be lost on exit
+				service.FlushViews()
 				os.Exit(1)
 			}
 		}
@@ -4,10 +4,13 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+	"github.com/willy182/boilerplate-go-cleanarch/config/storage"
+	"github.com/willy182/boilerplate-go-cleanarch/src/shared"
 	"github.com/willy182/boilerplate-go-cleanarch/utils"
-	// echoMid "github.com/labstack/echo/middleware"
 )
 
 // HTTPDefaultPort , default port for HTTP Server
@@ -21,18 +24,28 @@ func (hsi *HSIService) Serve() {
 		}
 	}()
 
+	if os.Getenv("APP_DEBUG") != "1" {
+		gin.SetMode(gin.ReleaseMode)
+	}
+
 	g := gin.New()
 
 	g.Use(gin.Recovery())
+	g.Use(callerMiddleware())
 
-	if os.Getenv("APP_DEBUG") == "1" {
-		g.Debug = true
+	// uploaded files of local storage are served by this server itself
+	if local, ok := hsi.Config.Storage.(*storage.LocalStorage); ok && strings.HasPrefix(local.URL, "/") {
+		g.Static(local.URL, local.Dir)
 	}
 
+	hsi.Article.Handler.V1.MountSitemap(g.Group("/"))
+
 	member := g.Group("/v1")
 
-	// version 4
+	// version 1
 	hsi.Article.Handler.V1.Mount(member)
+	hsi.Author.Handler.V1.Mount(member)
+	hsi.Comment.Handler.V1.Mount(member)
 
 	//start gin server
 	var port uint16
@@ -48,5 +61,16 @@ func (hsi *HSIService) Serve() {
 	}
 
 	listenerPort := fmt.Sprintf(":%d", port)
-	g.Logger.Fatal(g.Start(listenerPort))
+	if err := g.Run(listenerPort); err != nil {
+		utils.Log(log.FatalLevel, err.Error(), "Serve()", "run_server")
+	}
+}
+
+// callerMiddleware function for storing request caller into request context
+func callerMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		caller := shared.GetCaller(c.Request)
+		c.Request = c.Request.WithContext(shared.NewCallerContext(c.Request.Context(), caller))
+		c.Next()
+	}
 }
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/willy182/boilerplate-go-cleanarch/src/articles/v1/model"
+	"github.com/willy182/boilerplate-go-cleanarch/utils"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// SchedulerDefaultInterval , default interval in seconds for publishing scheduler
+	SchedulerDefaultInterval = 60
+	// SchedulerBatchSize , number of articles applied per statement by publishing scheduler
+	SchedulerBatchSize = 100
+)
+
+// Schedule function for running publishing scheduler of articles
+func (hsi *HSIService) Schedule() {
+	interval := SchedulerDefaultInterval
+	if intervalEnv, ok := os.LookupEnv("SCHEDULER_INTERVAL"); ok {
+		if intervalInt, err := strconv.Atoi(intervalEnv); err == nil && intervalInt > 0 {
+			interval = intervalInt
+		}
+	}
+
+	ticker := time.NewTicker(time.Duration(interval) * time.Second)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		hsi.applySchedule()
+	}
+}
+
+// applySchedule function for running one tick of publishing scheduler,
+// a panic is recovered per tick so the scheduler keeps running
+func (hsi *HSIService) applySchedule() {
+	defer func() {
+		if r := recover(); r != nil {
+			utils.Log(log.ErrorLevel, fmt.Sprint(r), "Schedule()", "recover_scheduler")
+		}
+	}()
+
+	res := <-hsi.Article.Usecase.ApplySchedule(context.Background(), SchedulerBatchSize)
+	if res.Error != nil {
+		utils.Log(log.ErrorLevel, res.Error.Error(), "Schedule()", "apply_schedule")
+		return
+	}
+
+	result := res.Result.(model.ScheduleResult)
+	if result.Published > 0 || result.Unpublished > 0 {
+		message := fmt.Sprintf("published: %d, unpublished: %d", result.Published, result.Unpublished)
+		utils.Log(log.InfoLevel, message, "Schedule()", "apply_schedule")
+	}
+}
@@ -6,6 +6,12 @@ import (
 	articleV1HTTP "github.com/willy182/boilerplate-go-cleanarch/src/articles/v1/delivery"
 	articleRepo "github.com/willy182/boilerplate-go-cleanarch/src/articles/v1/repository"
 	articleUseCase "github.com/willy182/boilerplate-go-cleanarch/src/articles/v1/usecase"
+	authorV1HTTP "github.com/willy182/boilerplate-go-cleanarch/src/authors/v1/delivery"
+	authorRepo "github.com/willy182/boilerplate-go-cleanarch/src/authors/v1/repository"
+	authorUseCase "github.com/willy182/boilerplate-go-cleanarch/src/authors/v1/usecase"
+	commentV1HTTP "github.com/willy182/boilerplate-go-cleanarch/src/comments/v1/delivery"
+	commentRepo "github.com/willy182/boilerplate-go-cleanarch/src/comments/v1/repository"
+	commentUseCase "github.com/willy182/boilerplate-go-cleanarch/src/comments/v1/usecase"
 )
 
 // HSIService main service structure
@@ -17,18 +23,43 @@ type HSIService struct {
 			V1 *articleV1HTTP.ArticleHandler
 		}
 	}
+	Author struct {
+		Usecase authorUseCase.UseCase
+		Handler struct {
+			V1 *authorV1HTTP.AuthorHandler
+		}
+	}
+	Comment struct {
+		Usecase commentUseCase.UseCase
+		Handler struct {
+			V1 *commentV1HTTP.CommentHandler
+		}
+	}
 }
 
 // InitHSIService function for initializing service
 func InitHSIService(conf *config.Config) *HSIService {
+	author := authorRepo.NewPostgresAuthorRepository(conf.PostgresDB.Read, conf.PostgresDB.Write)
+	authorUC := authorUseCase.NewAuthorUseCase(author)
+	authorV1Handler := authorV1HTTP.NewAuthorHTTPHandler(authorUC)
+
 	article := articleRepo.NewPostgresArticleRepository(conf.PostgresDB.Read, conf.PostgresDB.Write)
-	articleUC := articleUseCase.NewArticleUseCase(article)
+	articleUC := articleUseCase.NewArticleUseCase(article, author, conf.Storage)
 	articleV1Handler := articleV1HTTP.NewArticleHTTPHandler(articleUC)
+	articleV1Handler.Link = articleLink()
+
+	comment := commentRepo.NewPostgresCommentRepository(conf.PostgresDB.Read, conf.PostgresDB.Write)
+	commentUC := commentUseCase.NewCommentUseCase(comment, article)
+	commentV1Handler := commentV1HTTP.NewCommentHTTPHandler(commentUC)
 
 	hsi := new(HSIService)
 	hsi.Config = conf
-	hsi.Brand.Usecase = articleUC
-	hsi.Brand.Handler.V3 = articleV1Handler
+	hsi.Article.Usecase = articleUC
+	hsi.Article.Handler.V1 = articleV1Handler
+	hsi.Author.Usecase = authorUC
+	hsi.Author.Handler.V1 = authorV1Handler
+	hsi.Comment.Usecase = commentUC
+	hsi.Comment.Handler.V1 = commentV1Handler
 
 	return hsi
 }
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	articleV1HTTP "github.com/willy182/boilerplate-go-cleanarch/src/articles/v1/delivery"
+	"github.com/willy182/boilerplate-go-cleanarch/src/articles/v1/model"
+)
+
+// SitemapCommand , name of command line argument for writing sitemap into disk instead of serving
+const SitemapCommand = "sitemap"
+
+// articleLink function for getting link of public article page from SITE_URL and SITE_ARTICLE_PATH env,
+// api url of article is linked when SITE_URL is empty
+func articleLink() model.ArticleLink {
+	return model.ArticleLink{SiteURL: os.Getenv("SITE_URL"), PagePath: os.Getenv("SITE_ARTICLE_PATH")}
+}
+
+// RunSitemapCommand function for parsing sitemap command flags and writing sitemap into disk
+func (hsi *HSIService) RunSitemapCommand(args []string) error {
+	flags := flag.NewFlagSet(SitemapCommand, flag.ExitOnError)
+	dir := flags.String("dir", "public", "directory where sitemap.xml and its pages are written")
+	baseURL := flags.String("base-url", os.Getenv("SITE_URL"), "absolute url of the site, defaults to SITE_URL env")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *baseURL == "" {
+		return fmt.Errorf("base-url or SITE_URL env is required")
+	}
+
+	return hsi.WriteSitemap(*dir, *baseURL)
+}
+
+// WriteSitemap function for writing sitemap of published articles into dir with the same layout as served by HTTP,
+// sitemap pages are written next to the sitemap index when articles do not fit into a single sitemap
+func (hsi *HSIService) WriteSitemap(dir, baseURL string) error {
+	ctx := context.Background()
+
+	// sitemap written for the site links its article pages even when SITE_URL is not set
+	link := articleLink()
+	link.SiteURL = baseURL
+
+	res := <-hsi.Article.Usecase.GetSitemap(ctx, model.SitemapRoot)
+	if res.Error != nil {
+		return res.Error
+	}
+
+	sitemap := res.Result.(model.Sitemap)
+	if err := writeSitemapFile(filepath.Join(dir, "sitemap.xml"), baseURL, link, sitemap); err != nil {
+		return err
+	}
+
+	for _, page := range sitemap.Index {
+		resPage := <-hsi.Article.Usecase.GetSitemap(ctx, page.After)
+		if resPage.Error != nil {
+			return resPage.Error
+		}
+
+		filename := filepath.Join(dir, filepath.FromSlash(page.Path()))
+		if err := writeSitemapFile(filename, baseURL, link, resPage.Result.(model.Sitemap)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeSitemapFile function for writing sitemap into filename, the file is replaced atomically
+// so web server never serves partially written sitemap
+func writeSitemapFile(filename, baseURL string, link model.ArticleLink, sitemap model.Sitemap) error {
+	body, err := articleV1HTTP.SitemapXML(baseURL, link, sitemap)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+		return err
+	}
+
+	tmp := filename + ".tmp"
+	if err := ioutil.WriteFile(tmp, body, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, filename)
+}
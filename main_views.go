@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/willy182/boilerplate-go-cleanarch/utils"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// ViewsDefaultFlushInterval , default interval in seconds for writing buffered article views
+const ViewsDefaultFlushInterval = 10
+
+// CountViews function for writing buffered article views into their daily counters periodically
+func (hsi *HSIService) CountViews() {
+	defer func() {
+		if r := recover(); r != nil {
+			utils.Log(log.ErrorLevel, fmt.Sprint(r), "CountViews()", "recover_count_views")
+		}
+	}()
+
+	interval := ViewsDefaultFlushInterval
+	if intervalEnv, ok := os.LookupEnv("VIEWS_FLUSH_INTERVAL"); ok {
+		if intervalInt, err := strconv.Atoi(intervalEnv); err == nil && intervalInt > 0 {
+			interval = intervalInt
+		}
+	}
+
+	ticker := time.NewTicker(time.Duration(interval) * time.Second)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		hsi.FlushViews()
+	}
+}
+
+// FlushViews function for writing buffered article views at once, it is also called before the service exits
+func (hsi *HSIService) FlushViews() {
+	res := <-hsi.Article.Usecase.FlushViews(context.Background())
+	if res.Error != nil {
+		utils.Log(log.ErrorLevel, res.Error.Error(), "FlushViews()", "flush_views")
+	}
+}
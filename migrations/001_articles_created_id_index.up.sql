@@ -0,0 +1,2 @@
+-- index for keyset pagination of article list, seeking on (created, id)
+CREATE INDEX IF NOT EXISTS idx_articles_created_id ON articles (created DESC, id DESC);
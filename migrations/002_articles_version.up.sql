@@ -0,0 +1,2 @@
+-- version for optimistic concurrency control of article update
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS version integer NOT NULL DEFAULT 1;
@@ -0,0 +1,2 @@
+-- soft delete of article, NULL means the article is not deleted
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS deleted timestamp(6) with time zone;
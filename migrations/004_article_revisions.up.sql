@@ -0,0 +1,13 @@
+-- immutable snapshot of article written on every save, revision is the saved article version
+CREATE TABLE IF NOT EXISTS article_revisions (
+    id serial PRIMARY KEY,
+    article_id integer NOT NULL REFERENCES articles (id),
+    revision integer NOT NULL,
+    author varchar(100) NOT NULL DEFAULT '',
+    title varchar(100) NOT NULL,
+    summary varchar(250) NOT NULL,
+    description text,
+    image varchar(150),
+    created timestamp(6) with time zone NOT NULL DEFAULT now(),
+    UNIQUE (article_id, revision)
+);
@@ -0,0 +1,6 @@
+-- editorial workflow status of article, existing articles were public so they are kept published
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS status varchar(20) NOT NULL DEFAULT 'published';
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS published_at timestamp(6) with time zone;
+UPDATE articles SET published_at = created WHERE status = 'published' AND published_at IS NULL;
+ALTER TABLE articles ALTER COLUMN status SET DEFAULT 'draft';
+CREATE INDEX IF NOT EXISTS idx_articles_status ON articles (status);
@@ -0,0 +1,5 @@
+-- publishing schedule of article, applied by the scheduler and cleared once it is applied
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS publish_at timestamp(6) with time zone;
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS unpublish_at timestamp(6) with time zone;
+CREATE INDEX IF NOT EXISTS idx_articles_publish_at ON articles (publish_at) WHERE publish_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_articles_unpublish_at ON articles (unpublish_at) WHERE unpublish_at IS NOT NULL;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_articles_search_english;
+DROP INDEX IF EXISTS idx_articles_search_simple;
+ALTER TABLE articles DROP COLUMN IF EXISTS search_english;
+ALTER TABLE articles DROP COLUMN IF EXISTS search_simple;
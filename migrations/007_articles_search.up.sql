@@ -0,0 +1,13 @@
+-- full text search vector of article, one generated column for each supported text search configuration
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS search_simple tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', coalesce(title, '')), 'A') ||
+    setweight(to_tsvector('simple', coalesce(summary, '')), 'B') ||
+    setweight(to_tsvector('simple', coalesce(description, '')), 'C')
+) STORED;
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS search_english tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', coalesce(title, '')), 'A') ||
+    setweight(to_tsvector('english', coalesce(summary, '')), 'B') ||
+    setweight(to_tsvector('english', coalesce(description, '')), 'C')
+) STORED;
+CREATE INDEX IF NOT EXISTS idx_articles_search_simple ON articles USING GIN (search_simple);
+CREATE INDEX IF NOT EXISTS idx_articles_search_english ON articles USING GIN (search_english);
@@ -0,0 +1,15 @@
+-- url slug of article, existing articles get slug of their title suffixed by id to keep it unique
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS slug varchar(150);
+UPDATE articles SET slug = trim(BOTH '-' FROM regexp_replace(lower(title), '[^a-z0-9]+', '-', 'g')) || '-' || id
+WHERE slug IS NULL;
+UPDATE articles SET slug = ltrim(slug, '-') WHERE slug LIKE '-%';
+ALTER TABLE articles ALTER COLUMN slug SET NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_articles_slug ON articles (slug);
+
+-- old slug of article, used for redirecting into its current slug
+CREATE TABLE IF NOT EXISTS article_slug_histories (
+    slug varchar(150) PRIMARY KEY,
+    article_id integer NOT NULL REFERENCES articles (id),
+    created timestamp(6) with time zone NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_article_slug_histories_article_id ON article_slug_histories (article_id);
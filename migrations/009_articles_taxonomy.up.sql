@@ -0,0 +1,31 @@
+-- free form tags and editorial categories of article, both are referenced by their url slug
+CREATE TABLE IF NOT EXISTS tags (
+    id serial PRIMARY KEY,
+    name varchar(50) NOT NULL,
+    slug varchar(60) NOT NULL UNIQUE,
+    created timestamp(6) with time zone NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS categories (
+    id serial PRIMARY KEY,
+    name varchar(50) NOT NULL,
+    slug varchar(60) NOT NULL UNIQUE,
+    created timestamp(6) with time zone NOT NULL DEFAULT now()
+);
+
+-- many to many relation of article into its tags and categories, removed together with the article or the term
+CREATE TABLE IF NOT EXISTS article_tags (
+    article_id integer NOT NULL REFERENCES articles (id) ON DELETE CASCADE,
+    tag_id integer NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+    created timestamp(6) with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY (article_id, tag_id)
+);
+CREATE INDEX IF NOT EXISTS idx_article_tags_tag_id ON article_tags (tag_id);
+
+CREATE TABLE IF NOT EXISTS article_categories (
+    article_id integer NOT NULL REFERENCES articles (id) ON DELETE CASCADE,
+    category_id integer NOT NULL REFERENCES categories (id) ON DELETE CASCADE,
+    created timestamp(6) with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY (article_id, category_id)
+);
+CREATE INDEX IF NOT EXISTS idx_article_categories_category_id ON article_categories (category_id);
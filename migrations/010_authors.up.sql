@@ -0,0 +1,14 @@
+-- author profile of articles, email is unique so an author is registered only once
+CREATE TABLE IF NOT EXISTS authors (
+    id serial PRIMARY KEY,
+    name varchar(100) NOT NULL,
+    email varchar(150) NOT NULL UNIQUE,
+    bio text,
+    avatar varchar(150),
+    created timestamp(6) with time zone NOT NULL DEFAULT now(),
+    modified timestamp(6) with time zone
+);
+
+-- owner of article, existing articles are left without author
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS author_id integer REFERENCES authors (id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_articles_author_id ON articles (author_id);
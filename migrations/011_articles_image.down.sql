@@ -0,0 +1,3 @@
+ALTER TABLE articles DROP COLUMN IF EXISTS image_variants;
+ALTER TABLE article_revisions ALTER COLUMN image TYPE varchar(150) USING left(image, 150);
+ALTER TABLE articles ALTER COLUMN image TYPE varchar(150) USING left(image, 150);
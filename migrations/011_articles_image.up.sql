@@ -0,0 +1,6 @@
+-- url of uploaded image is longer than the former 150 characters limit
+ALTER TABLE articles ALTER COLUMN image TYPE varchar(500);
+ALTER TABLE article_revisions ALTER COLUMN image TYPE varchar(500);
+
+-- url of resized image variants keyed by variant name
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS image_variants jsonb;
@@ -0,0 +1,3 @@
+-- sanitized html rendered from markdown description, it is filled on the next save of each article
+-- and rendered on the fly until then
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS description_html text;
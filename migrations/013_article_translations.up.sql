@@ -0,0 +1,12 @@
+-- translated content of article per locale, the article row itself holds content of the default locale
+CREATE TABLE IF NOT EXISTS article_translations (
+    article_id integer NOT NULL REFERENCES articles (id) ON DELETE CASCADE,
+    locale varchar(10) NOT NULL,
+    title varchar(100) NOT NULL,
+    summary varchar(250) NOT NULL,
+    description text,
+    description_html text,
+    created timestamp(6) with time zone NOT NULL DEFAULT now(),
+    modified timestamp(6) with time zone,
+    PRIMARY KEY (article_id, locale)
+);
@@ -0,0 +1,2 @@
+-- trigram similarity of article text is one of the signals for ranking related articles
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
@@ -0,0 +1,8 @@
+-- number of views of article per day, views are buffered by the service and added in batches
+CREATE TABLE IF NOT EXISTS article_daily_views (
+    article_id integer NOT NULL REFERENCES articles (id) ON DELETE CASCADE,
+    day date NOT NULL,
+    views bigint NOT NULL DEFAULT 0,
+    PRIMARY KEY (article_id, day)
+);
+CREATE INDEX IF NOT EXISTS idx_article_daily_views_day ON article_daily_views (day);
@@ -0,0 +1,18 @@
+-- threaded comment of article, only approved comment is visible to public
+CREATE TABLE IF NOT EXISTS comments (
+    id serial PRIMARY KEY,
+    article_id integer NOT NULL REFERENCES articles (id) ON DELETE CASCADE,
+    parent_id integer REFERENCES comments (id) ON DELETE CASCADE,
+    author_name varchar(100) NOT NULL,
+    author_email varchar(150) NOT NULL,
+    body text NOT NULL,
+    status varchar(20) NOT NULL DEFAULT 'pending',
+    created timestamp(6) with time zone NOT NULL DEFAULT now(),
+    modified timestamp(6) with time zone
+);
+CREATE INDEX IF NOT EXISTS idx_comments_article_id_created ON comments (article_id, created) WHERE parent_id IS NULL;
+CREATE INDEX IF NOT EXISTS idx_comments_parent_id ON comments (parent_id);
+CREATE INDEX IF NOT EXISTS idx_comments_status_created ON comments (status, created);
+
+-- number of approved comments of article, it is kept by the comment repository on every moderation
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS comment_count integer NOT NULL DEFAULT 0;
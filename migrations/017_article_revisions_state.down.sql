@@ -0,0 +1,3 @@
+ALTER TABLE article_revisions DROP COLUMN IF EXISTS author_id;
+ALTER TABLE article_revisions DROP COLUMN IF EXISTS slug;
+ALTER TABLE article_revisions DROP COLUMN IF EXISTS status;
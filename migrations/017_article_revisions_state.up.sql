@@ -0,0 +1,4 @@
+-- workflow status, slug and owner of article at the saved revision, revisions written before are left without them
+ALTER TABLE article_revisions ADD COLUMN IF NOT EXISTS status varchar(20);
+ALTER TABLE article_revisions ADD COLUMN IF NOT EXISTS slug varchar(150);
+ALTER TABLE article_revisions ADD COLUMN IF NOT EXISTS author_id integer REFERENCES authors (id) ON DELETE SET NULL;
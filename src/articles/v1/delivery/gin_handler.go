@@ -1,10 +1,12 @@
 package delivery
 
 import (
-	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/willy182/boilerplate-go-cleanarch/src/articles/v1/model"
 	"github.com/willy182/boilerplate-go-cleanarch/src/articles/v1/usecase"
@@ -18,6 +20,8 @@ import (
 // ArticleHandler struct for http brand handling
 type ArticleHandler struct {
 	ArticleUseCase usecase.UseCase
+	// Link builds url of article in sitemap and feed, api url is used when it is zero
+	Link model.ArticleLink
 }
 
 // NewArticleHTTPHandler route handler for article
@@ -27,111 +31,781 @@ func NewArticleHTTPHandler(usecase usecase.UseCase) *ArticleHandler {
 
 // Mount function
 func (h *ArticleHandler) Mount(group *gin.RouterGroup) {
-	// group.POST("/article", h.GetAll)
+	group.GET("/articles", h.GetAll)
+	group.GET("/articles/search", h.Search)
+	group.GET("/articles/popular", h.GetPopular)
+	group.POST("/articles/import", h.Import)
+	group.GET("/articles/export", h.Export)
+	group.POST("/articles/batch", h.Batch)
+	group.GET("/authors/:id/articles", h.GetAllByAuthor)
+	group.GET("/tags", h.GetTags)
+	group.GET("/categories", h.GetCategories)
+	group.GET("/feeds/articles.rss", h.FeedRSS)
+	group.GET("/feeds/articles.atom", h.FeedAtom)
+	group.GET("/feeds/tags/:tag/articles.rss", h.FeedRSS)
+	group.GET("/feeds/tags/:tag/articles.atom", h.FeedAtom)
+	group.GET("/feeds/authors/:id/articles.rss", h.FeedRSS)
+	group.GET("/feeds/authors/:id/articles.atom", h.FeedAtom)
+	group.POST("/article", h.Create)
 	group.GET("/article/:id", h.GetByID)
+	group.GET("/article/slug/:slug", h.GetBySlug)
+	group.PUT("/article/:id", h.Update)
+	group.PATCH("/article/:id", h.Patch)
+	group.DELETE("/article/:id", h.Delete)
+	group.POST("/article/:id/restore", h.Restore)
+	group.POST("/article/:id/status", h.ChangeStatus)
+	group.POST("/article/:id/schedule", h.Schedule)
+	group.POST("/article/:id/image", h.UploadImage)
+	group.POST("/article/:id/tags", h.AttachTags)
+	group.DELETE("/article/:id/tags/:tag", h.DetachTag)
+	group.POST("/article/:id/categories", h.AttachCategories)
+	group.DELETE("/article/:id/categories/:category", h.DetachCategory)
+	group.GET("/article/:id/related", h.GetRelated)
+	group.POST("/article/:id/translations", h.SaveTranslation)
+	group.GET("/article/:id/translations", h.GetTranslations)
+	group.GET("/article/:id/revisions", h.GetRevisions)
+	group.GET("/article/:id/revisions/:rev", h.GetRevision)
+	group.GET("/article/:id/revisions/:rev/diff/:to", h.DiffRevisions)
+	group.POST("/article/:id/revisions/:rev/restore", h.RestoreRevision)
+}
+
+// validateID function for validating id route param, response will be written when id is not valid
+func (h *ArticleHandler) validateID(c *gin.Context, ctxHandler string) (int, bool) {
+	return h.validateNumericParam(c, ctxHandler, "id")
+}
+
+// validateNumericParam function for validating numeric route param, response will be written when param is not valid
+func (h *ArticleHandler) validateNumericParam(c *gin.Context, ctxHandler string, name string) (int, bool) {
+	param := c.Param(name)
+	multiError := shared.NewMultiError()
+
+	if ok := shared.ValidateNumeric(param); !ok {
+		multiError.Append("error", fmt.Errorf("%s must be numeric", name))
+		utils.Log(log.ErrorLevel, multiError.Error(), ctxHandler, "validate_"+name)
+		response := shared.NewHTTPResponse(http.StatusBadRequest, "validate "+name, multiError)
+		response.JSON(c.Writer)
+		return 0, false
+	}
+
+	value, _ := strconv.Atoi(param)
+	return value, true
+}
+
+// validateVersion function for getting expected article version from If-Match header or payload version,
+// response will be written when version is not valid or not sent
+func (h *ArticleHandler) validateVersion(c *gin.Context, ctxHandler string, payloadVersion int) (int, bool) {
+	multiError := shared.NewMultiError()
+	version := payloadVersion
+
+	if ifMatch := c.GetHeader("If-Match"); ifMatch != "" {
+		value := strings.Trim(ifMatch, `"`)
+		if !shared.ValidateNumeric(value) {
+			multiError.Append("If-Match", fmt.Errorf("header If-Match must be quoted article version"))
+			utils.Log(log.ErrorLevel, multiError.Error(), ctxHandler, "validate_version")
+			response := shared.NewHTTPResponse(http.StatusBadRequest, "validate version", multiError)
+			response.JSON(c.Writer)
+			return 0, false
+		}
+		version, _ = strconv.Atoi(value)
+	}
+
+	if version <= 0 {
+		multiError.Append("version", fmt.Errorf("version is required, send it in If-Match header or payload"))
+		utils.Log(log.ErrorLevel, multiError.Error(), ctxHandler, "validate_version")
+		response := shared.NewHTTPResponse(http.StatusPreconditionRequired, "validate version", multiError)
+		response.JSON(c.Writer)
+		return 0, false
+	}
+
+	return version, true
+}
+
+// errorStatusCode function for mapping use case error into http status code
+func errorStatusCode(err error) int {
+	switch {
+	case errors.Is(err, shared.ErrDataNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, shared.ErrDataConflict), errors.Is(err, model.ErrSlugUsed):
+		return http.StatusConflict
+	case errors.Is(err, shared.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, model.ErrInvalidTransition), errors.Is(err, model.ErrAuthorNotFound),
+		errors.Is(err, model.ErrImageDimension):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, model.ErrImageType):
+		return http.StatusUnsupportedMediaType
+	case errors.Is(err, model.ErrImageTooLarge):
+		return http.StatusRequestEntityTooLarge
+	default:
+		return http.StatusBadRequest
+	}
 }
 
 // GetByID method for handling route article by ID
-func (h *ArticleHandler) GetByID(c gin.Context) error {
+func (h *ArticleHandler) GetByID(c *gin.Context) {
 	ctxHandler := "article_handler_get_by_id"
-	idParam := c.Param("id")
-	ctx := context.Context()
+	ctx := c.Request.Context()
 	multiError := shared.NewMultiError()
 
-	if ok := shared.ValidateNumeric(idParam); !ok {
-		multiError.Append("error", fmt.Errorf("id must be numeric"))
-		utils.Log(log.ErrorLevel, multiError.Error(), ctxHandler, "validate_id")
-		response := shared.NewHTTPResponse(http.StatusBadRequest, "validate id", multiError)
-		return response.JSON(c.Response())
+	id, ok := h.validateID(c, ctxHandler)
+	if !ok {
+		return
 	}
 
-	multiError.Clear()
+	fields, include, format, ok := h.validateRepresentation(c, ctxHandler)
+	if !ok {
+		return
+	}
+
+	locales, ok := h.negotiateLocales(c, ctxHandler)
+	if !ok {
+		return
+	}
 
-	id, _ := strconv.Atoi(idParam)
-	res := <-h.ArticleUseCase.GetByID(ctx, id)
+	res := <-h.ArticleUseCase.GetByID(ctx, id, fields...)
 	if res.Error != nil {
 		utils.Log(log.ErrorLevel, res.Error.Error(), ctxHandler, "err_res_get_by_id")
-		response := shared.NewHTTPResponse(http.StatusBadRequest, res.Error.Error(), multiError)
-		return response.JSON(c.Response())
+		response := shared.NewHTTPResponse(errorStatusCode(res.Error), res.Error.Error(), multiError)
+		response.JSON(c.Writer)
+		return
 	}
 
+	// conditional request answered with not modified is not counted as view
 	result := res.Result.(model.Article)
+	if h.writeArticle(c, ctxHandler, result, fields, include, locales, format, "Article Get By ID") {
+		h.ArticleUseCase.RecordView(ctx, result)
+	}
+}
+
+// validateRepresentation function for validating fields, include and format query params of single article,
+// response will be written when they are not valid
+func (h *ArticleHandler) validateRepresentation(c *gin.Context, ctxHandler string) ([]string, []string, string, bool) {
+	multiError := shared.NewMultiError()
+	fields := parseFields(c.Query("fields"), multiError)
+	include := parseInclude(c.Query("include"), multiError)
+
+	format := c.DefaultQuery("format", model.FormatMarkdown)
+	if !shared.StringInSlice(format, model.DescriptionFormats) {
+		multiError.Append("format", fmt.Errorf("format must be one of %s", strings.Join(model.DescriptionFormats, ", ")))
+	}
+
+	if multiError.HasError() {
+		utils.Log(log.ErrorLevel, multiError.Error(), ctxHandler, "validate_params")
+		response := shared.NewHTTPResponse(http.StatusBadRequest, "validate params", multiError)
+		response.JSON(c.Writer)
+		return nil, nil, "", false
+	}
+
+	return fields, include, format, true
+}
+
+// writeArticle function for writing single article response of requested fields, include and locales with its cache validators,
+// not modified response is written when client already has the current article, true is returned only when the article body is written
+func (h *ArticleHandler) writeArticle(c *gin.Context, ctxHandler string, result model.Article, fields, include, locales []string,
+	format, message string) bool {
+	articles, ok := h.includeArticles(c, ctxHandler, []model.Article{result}, include)
+	if !ok {
+		return false
+	}
+
+	articles, ok = h.translateArticles(c, ctxHandler, articles, locales)
+	if !ok {
+		return false
+	}
+	result = articles[0]
+	formatDescription(&result, format)
+	c.Header("Content-Language", result.Locale)
+
+	// modified is only precise to the second, version keeps the tag strong for updates within the same second,
+	// comment count changes without touching the article, each representation of requested fields, include,
+	// locale and format gets its own tag
+	etagParts := []interface{}{result.ID, result.Modified, result.Version, result.CommentCount, strings.Join(fields, ","),
+		strings.Join(include, ","), result.Locale, format}
+	if result.Author != nil {
+		etagParts = append(etagParts, result.Author.ID, result.Author.Modified)
+	}
+
+	etag := shared.GenerateETag(etagParts...)
+	lastModified := result.LastModified()
+	shared.SetCacheHeaders(c.Writer, etag, lastModified)
+	if shared.IsNotModified(c.Request, etag, lastModified) {
+		c.AbortWithStatus(http.StatusNotModified)
+		return false
+	}
+
+	var data interface{} = result
+	if len(fields) > 0 {
+		data = result.Project(fields, include)
+	}
+
 	meta := shared.CreateMeta(1, 1, 1)
-	response := shared.NewHTTPResponse(http.StatusOK, "Article Get By ID", result, meta)
-	return response.JSON(c.Response())
+	response := shared.NewHTTPResponse(http.StatusOK, message, data, meta)
+	response.JSON(c.Writer)
+	return true
+}
+
+// formatDescription function for converting description of article into requested format,
+// html of article saved before html was stored is rendered on the fly
+func formatDescription(article *model.Article, format string) {
+	if format == model.FormatMarkdown || article.Description == "" {
+		return
+	}
+
+	if article.DescriptionHTML == "" {
+		article.DescriptionHTML = shared.RenderMarkdown(article.Description)
+	}
+
+	article.Description = article.DescriptionHTML
+	if format == model.FormatText {
+		article.Description = shared.HTMLToText(article.DescriptionHTML)
+	}
+}
+
+// articleListData function for getting response data of article list, article is projected into requested fields
+func articleListData(articles []model.Article, fields, include []string) interface{} {
+	if len(fields) == 0 {
+		return articles
+	}
+
+	data := make([]map[string]interface{}, 0, len(articles))
+	for i := range articles {
+		data = append(data, articles[i].Project(fields, include))
+	}
+	return data
+}
+
+// includeArticles function for expanding include relations of articles,
+// response will be written when the relations can not be loaded
+func (h *ArticleHandler) includeArticles(c *gin.Context, ctxHandler string, articles []model.Article, include []string) ([]model.Article, bool) {
+	if len(include) == 0 || len(articles) == 0 {
+		return articles, true
+	}
+
+	res := <-h.ArticleUseCase.Include(c.Request.Context(), articles, include)
+	if res.Error != nil {
+		utils.Log(log.ErrorLevel, res.Error.Error(), ctxHandler, "err_res_include")
+		response := shared.NewHTTPResponse(errorStatusCode(res.Error), res.Error.Error(), shared.NewMultiError())
+		response.JSON(c.Writer)
+		return nil, false
+	}
+
+	return res.Result.([]model.Article), true
+}
+
+// validateSlug function for validating requested slug, response will be written when slug is not valid
+func (h *ArticleHandler) validateSlug(c *gin.Context, ctxHandler string, slug string) bool {
+	if err := shared.ValidateSlug(slug); err != nil {
+		multiError := shared.NewMultiError()
+		multiError.Append("slug", err)
+		utils.Log(log.ErrorLevel, multiError.Error(), ctxHandler, "validate_slug")
+		response := shared.NewHTTPResponse(http.StatusBadRequest, "validate slug", multiError)
+		response.JSON(c.Writer)
+		return false
+	}
+	return true
+}
+
+// Create method for handling route create article
+func (h *ArticleHandler) Create(c *gin.Context) {
+	ctxHandler := "article_handler_create"
+	ctx := c.Request.Context()
+	multiError := shared.NewMultiError()
+
+	var param model.ArticleRequest
+	if err := c.ShouldBindJSON(&param); err != nil {
+		multiError.Append("error", err)
+		utils.Log(log.ErrorLevel, multiError.Error(), ctxHandler, "bind_payload")
+		response := shared.NewHTTPResponse(http.StatusBadRequest, "bind payload", multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	if param.Slug != "" && !h.validateSlug(c, ctxHandler, param.Slug) {
+		return
+	}
+
+	res := <-h.ArticleUseCase.Create(ctx, param)
+	if res.Error != nil {
+		utils.Log(log.ErrorLevel, res.Error.Error(), ctxHandler, "err_res_create")
+		response := shared.NewHTTPResponse(errorStatusCode(res.Error), res.Error.Error(), multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	result := res.Result.(model.Article)
+	response := shared.NewHTTPResponse(http.StatusCreated, "Article Created", result)
+	response.JSON(c.Writer)
+}
+
+// Update method for handling route replace article by ID
+func (h *ArticleHandler) Update(c *gin.Context) {
+	ctxHandler := "article_handler_update"
+	ctx := c.Request.Context()
+	multiError := shared.NewMultiError()
+
+	id, ok := h.validateID(c, ctxHandler)
+	if !ok {
+		return
+	}
+
+	var param model.ArticleRequest
+	if err := c.ShouldBindJSON(&param); err != nil {
+		multiError.Append("error", err)
+		utils.Log(log.ErrorLevel, multiError.Error(), ctxHandler, "bind_payload")
+		response := shared.NewHTTPResponse(http.StatusBadRequest, "bind payload", multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	if param.Version, ok = h.validateVersion(c, ctxHandler, param.Version); !ok {
+		return
+	}
+
+	if param.Slug != "" && !h.validateSlug(c, ctxHandler, param.Slug) {
+		return
+	}
+
+	res := <-h.ArticleUseCase.Update(ctx, id, param)
+	if res.Error != nil {
+		utils.Log(log.ErrorLevel, res.Error.Error(), ctxHandler, "err_res_update")
+		response := shared.NewHTTPResponse(errorStatusCode(res.Error), res.Error.Error(), multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	result := res.Result.(model.Article)
+	response := shared.NewHTTPResponse(http.StatusOK, "Article Updated", result)
+	response.JSON(c.Writer)
+}
+
+// Patch method for handling route partial update article by ID
+func (h *ArticleHandler) Patch(c *gin.Context) {
+	ctxHandler := "article_handler_patch"
+	ctx := c.Request.Context()
+	multiError := shared.NewMultiError()
+
+	id, ok := h.validateID(c, ctxHandler)
+	if !ok {
+		return
+	}
+
+	var param model.ArticlePatchRequest
+	if err := c.ShouldBindJSON(&param); err != nil {
+		multiError.Append("error", err)
+		utils.Log(log.ErrorLevel, multiError.Error(), ctxHandler, "bind_payload")
+		response := shared.NewHTTPResponse(http.StatusBadRequest, "bind payload", multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	if param.Version, ok = h.validateVersion(c, ctxHandler, param.Version); !ok {
+		return
+	}
+
+	if param.Slug != nil && !h.validateSlug(c, ctxHandler, *param.Slug) {
+		return
+	}
+
+	res := <-h.ArticleUseCase.Patch(ctx, id, param)
+	if res.Error != nil {
+		utils.Log(log.ErrorLevel, res.Error.Error(), ctxHandler, "err_res_patch")
+		response := shared.NewHTTPResponse(errorStatusCode(res.Error), res.Error.Error(), multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	result := res.Result.(model.Article)
+	response := shared.NewHTTPResponse(http.StatusOK, "Article Updated", result)
+	response.JSON(c.Writer)
+}
+
+// Delete method for handling route delete article by ID
+func (h *ArticleHandler) Delete(c *gin.Context) {
+	ctxHandler := "article_handler_delete"
+	ctx := c.Request.Context()
+	multiError := shared.NewMultiError()
+
+	id, ok := h.validateID(c, ctxHandler)
+	if !ok {
+		return
+	}
+
+	if err := <-h.ArticleUseCase.Delete(ctx, id); err != nil {
+		utils.Log(log.ErrorLevel, err.Error(), ctxHandler, "err_res_delete")
+		response := shared.NewHTTPResponse(errorStatusCode(err), err.Error(), multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	response := shared.NewHTTPResponse(http.StatusOK, "Article Deleted")
+	response.JSON(c.Writer)
+}
+
+// Restore method for handling route restore soft deleted article by ID
+func (h *ArticleHandler) Restore(c *gin.Context) {
+	ctxHandler := "article_handler_restore"
+	ctx := c.Request.Context()
+	multiError := shared.NewMultiError()
+
+	id, ok := h.validateID(c, ctxHandler)
+	if !ok {
+		return
+	}
+
+	res := <-h.ArticleUseCase.Restore(ctx, id)
+	if res.Error != nil {
+		utils.Log(log.ErrorLevel, res.Error.Error(), ctxHandler, "err_res_restore")
+		response := shared.NewHTTPResponse(errorStatusCode(res.Error), res.Error.Error(), multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	result := res.Result.(model.Article)
+	response := shared.NewHTTPResponse(http.StatusOK, "Article Restored", result)
+	response.JSON(c.Writer)
+}
+
+// ChangeStatus method for handling route change status of article by ID
+func (h *ArticleHandler) ChangeStatus(c *gin.Context) {
+	ctxHandler := "article_handler_change_status"
+	ctx := c.Request.Context()
+	multiError := shared.NewMultiError()
+
+	id, ok := h.validateID(c, ctxHandler)
+	if !ok {
+		return
+	}
+
+	var param model.ArticleStatusRequest
+	if err := c.ShouldBindJSON(&param); err != nil {
+		multiError.Append("error", err)
+		utils.Log(log.ErrorLevel, multiError.Error(), ctxHandler, "bind_payload")
+		response := shared.NewHTTPResponse(http.StatusBadRequest, "bind payload", multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	if !shared.StringInSlice(param.Status, model.ArticleStatuses) {
+		multiError.Append("status", fmt.Errorf("status %s is not valid", param.Status))
+		utils.Log(log.ErrorLevel, multiError.Error(), ctxHandler, "validate_status")
+		response := shared.NewHTTPResponse(http.StatusBadRequest, "validate status", multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	if param.Version, ok = h.validateVersion(c, ctxHandler, param.Version); !ok {
+		return
+	}
+
+	res := <-h.ArticleUseCase.ChangeStatus(ctx, id, param.Status, param.Version)
+	if res.Error != nil {
+		utils.Log(log.ErrorLevel, res.Error.Error(), ctxHandler, "err_res_change_status")
+		response := shared.NewHTTPResponse(errorStatusCode(res.Error), res.Error.Error(), multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	result := res.Result.(model.Article)
+	response := shared.NewHTTPResponse(http.StatusOK, "Article Status Changed", result)
+	response.JSON(c.Writer)
+}
+
+// Schedule method for handling route set publishing schedule of article by ID
+func (h *ArticleHandler) Schedule(c *gin.Context) {
+	ctxHandler := "article_handler_schedule"
+	ctx := c.Request.Context()
+	multiError := shared.NewMultiError()
+
+	id, ok := h.validateID(c, ctxHandler)
+	if !ok {
+		return
+	}
+
+	var param model.ArticleScheduleRequest
+	if err := c.ShouldBindJSON(&param); err != nil {
+		multiError.Append("error", err)
+		utils.Log(log.ErrorLevel, multiError.Error(), ctxHandler, "bind_payload")
+		response := shared.NewHTTPResponse(http.StatusBadRequest, "bind payload", multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	if param.PublishAt != nil && param.UnpublishAt != nil && !param.UnpublishAt.After(*param.PublishAt) {
+		multiError.Append("unpublishAt", fmt.Errorf("unpublishAt must be after publishAt"))
+		utils.Log(log.ErrorLevel, multiError.Error(), ctxHandler, "validate_schedule")
+		response := shared.NewHTTPResponse(http.StatusBadRequest, "validate schedule", multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	if param.Version, ok = h.validateVersion(c, ctxHandler, param.Version); !ok {
+		return
+	}
+
+	res := <-h.ArticleUseCase.Schedule(ctx, id, param)
+	if res.Error != nil {
+		utils.Log(log.ErrorLevel, res.Error.Error(), ctxHandler, "err_res_schedule")
+		response := shared.NewHTTPResponse(errorStatusCode(res.Error), res.Error.Error(), multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	result := res.Result.(model.Article)
+	response := shared.NewHTTPResponse(http.StatusOK, "Article Scheduled", result)
+	response.JSON(c.Writer)
 }
 
 // GetAll method for handling route for get article list
-// func (h *ArticleHandler) GetAll(c gin.Context) error {
-// 	ctxHandler := "article_handler_get_all"
-// 	ctx := context.Context()
-// 	multiError := shared.NewMultiError()
-// 	var params model.CategoryRequestParams
-
-// 	err := shared.BindQueryParam(req.URL, &params)
-// 	if err != nil {
-// 		multiError.Append("error", err)
-// 		utils.Log(log.ErrorLevel, multiError.Error(), ctxHandler, "bind_params")
-// 		response := shared.NewHTTPResponse(http.StatusBadRequest, "bind params", multiError)
-// 		return response.JSON(c.Response())
-// 	}
-
-// 	multiError.Clear()
-
-// 	params.FilterQuery, _ = url.PathUnescape(params.FilterQuery)
-
-// 	multiError = jsonschema.Validate("article_get_params", params)
-// 	if multiError != nil && multiError.HasError() {
-// 		golib.Log(golib.ErrorLevel, multiError.Error(), ctxHandler, "validate_params")
-// 		tracer.Log(ctx, ctxHandler, "validate_params", params)
-// 		response := golib.NewHTTPResponse(http.StatusBadRequest, "validate params", multiError)
-// 		return response.JSON(c.Response())
-// 	}
-
-// 	if params.PageSize == "0" {
-// 		params.PageSize = ""
-// 	}
-
-// 	if params.PageNumber == "0" {
-// 		params.PageNumber = ""
-// 	}
-
-// 	var categoryParams model.CategoryParams
-// 	categoryParams.FilterQuery = params.FilterQuery
-// 	categoryParams.FilterOldID = params.FilterOldID
-// 	categoryParams.FilterStatus = params.FilterStatus
-// 	categoryParams.FilterCurrentLevel = params.FilterCurrentLevel
-// 	categoryParams.FieldsCategories = fieldsCategories
-// 	categoryParams.Sort = params.Sort
-// 	categoryParams.PageSize = params.PageSize
-// 	categoryParams.PageNumber = params.PageNumber
-// 	categoryParams.Include = params.Include
-// 	categoryParams.NoCache = params.NoCache
-
-// 	res := <-h.ArticleUseCase.GetAll(ctx, categoryParams, req)
-// 	if res.Error != nil {
-// 		golib.Log(golib.ErrorLevel, res.Error.Error(), ctxHandler, "err_res_get_all")
-// 		tracer.Log(ctx, ctxHandler, "err_res_get_all", res.Error.Error(), categoryParams)
-// 		response := golib.NewHTTPResponse(http.StatusBadRequest, res.Error.Error(), multiError)
-// 		return response.JSON(c.Response())
-// 	}
-
-// 	result := res.Result.([]model.Article)
-
-// 	var (
-// 		page  = 1
-// 		limit = helpers.LimitDefault
-// 	)
-
-// 	if params.PageNumber != "" {
-// 		page, _ = strconv.Atoi(params.PageNumber)
-// 	}
-
-// 	if params.PageSize != "" {
-// 		limit, _ = strconv.Atoi(params.PageSize)
-// 	}
-
-// 	meta := shared.CreateMeta(result.Total, page, limit)
-// 	response := golib.NewHTTPResponse(http.StatusOK, "Article List", result.Data, meta)
-// 	return response.JSON(c.Response())
-// }
+func (h *ArticleHandler) GetAll(c *gin.Context) {
+	ctxHandler := "article_handler_get_all"
+	ctx := c.Request.Context()
+	multiError := shared.NewMultiError()
+
+	var params model.ArticleRequestParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		multiError.Append("error", err)
+		utils.Log(log.ErrorLevel, multiError.Error(), ctxHandler, "bind_params")
+		response := shared.NewHTTPResponse(http.StatusBadRequest, "bind params", multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	_, isCursor := c.GetQuery("cursor")
+	articleParams, multiError := buildArticleParams(params, isCursor)
+	if multiError.HasError() {
+		utils.Log(log.ErrorLevel, multiError.Error(), ctxHandler, "validate_params")
+		response := shared.NewHTTPResponse(http.StatusBadRequest, "validate params", multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	if articleParams.IncludeDeleted && !shared.CallerFromContext(ctx).IsAdmin() {
+		multiError.Append("include_deleted", fmt.Errorf("include_deleted is only allowed for admin"))
+		utils.Log(log.ErrorLevel, multiError.Error(), ctxHandler, "validate_caller")
+		response := shared.NewHTTPResponse(http.StatusForbidden, "validate caller", multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	locales, ok := h.negotiateLocales(c, ctxHandler)
+	if !ok {
+		return
+	}
+
+	if articleParams.Cursor != nil {
+		res := <-h.ArticleUseCase.GetAllByCursor(ctx, articleParams)
+		if res.Error != nil {
+			utils.Log(log.ErrorLevel, res.Error.Error(), ctxHandler, "err_res_get_all_by_cursor")
+			response := shared.NewHTTPResponse(errorStatusCode(res.Error), res.Error.Error(), multiError)
+			response.JSON(c.Writer)
+			return
+		}
+
+		result := res.Result.(model.ArticleCursorList)
+		articles, ok := h.includeArticles(c, ctxHandler, result.Data, articleParams.Include)
+		if !ok {
+			return
+		}
+
+		articles, ok = h.translateArticles(c, ctxHandler, articles, locales)
+		if !ok {
+			return
+		}
+
+		data := articleListData(articles, articleParams.Fields, articleParams.Include)
+		meta := shared.CreateCursorMeta(articleParams.Limit, result.Next.Encode(), result.Prev.Encode())
+		response := shared.NewHTTPResponse(http.StatusOK, "Article List", data, meta)
+		response.JSON(c.Writer)
+		return
+	}
+
+	res := <-h.ArticleUseCase.GetAll(ctx, articleParams)
+	if res.Error != nil {
+		utils.Log(log.ErrorLevel, res.Error.Error(), ctxHandler, "err_res_get_all")
+		response := shared.NewHTTPResponse(errorStatusCode(res.Error), res.Error.Error(), multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	result := res.Result.(model.ArticleList)
+	articles, ok := h.includeArticles(c, ctxHandler, result.Data, articleParams.Include)
+	if !ok {
+		return
+	}
+
+	articles, ok = h.translateArticles(c, ctxHandler, articles, locales)
+	if !ok {
+		return
+	}
+
+	data := articleListData(articles, articleParams.Fields, articleParams.Include)
+	meta := shared.CreateMeta(result.Total, articleParams.Page, articleParams.Limit)
+	response := shared.NewHTTPResponse(http.StatusOK, "Article List", data, meta)
+	response.JSON(c.Writer)
+}
+
+// buildArticleParams function for validating and converting article list query params,
+// isCursor is true when the list is requested using keyset pagination
+func buildArticleParams(params model.ArticleRequestParams, isCursor bool) (model.ArticleParams, *shared.MultiError) {
+	multiError := shared.NewMultiError()
+	articleParams := model.ArticleParams{
+		Query: strings.TrimSpace(params.Query),
+	}
+
+	articleParams.Page, articleParams.Limit = shared.ParsePagination(params.PageNumber, params.PageSize, multiError)
+
+	for _, status := range strings.Split(params.Status, ",") {
+		status = strings.TrimSpace(status)
+		if status == "" {
+			continue
+		}
+
+		if !shared.StringInSlice(status, model.ArticleStatuses) {
+			multiError.Append("status", fmt.Errorf("status %s is not valid", status))
+			continue
+		}
+		articleParams.Status = append(articleParams.Status, status)
+	}
+
+	articleParams.Fields = parseFields(params.Fields, multiError)
+	articleParams.Include = parseInclude(params.Include, multiError)
+
+	articleParams.Tag = strings.TrimSpace(params.Tag)
+	if articleParams.Tag != "" {
+		if err := shared.ValidateSlug(articleParams.Tag); err != nil {
+			multiError.Append("tag", err)
+		}
+	}
+
+	articleParams.Category = strings.TrimSpace(params.Category)
+	if articleParams.Category != "" {
+		if err := shared.ValidateSlug(articleParams.Category); err != nil {
+			multiError.Append("category", err)
+		}
+	}
+
+	if params.IncludeDeleted != "" {
+		includeDeleted, err := strconv.ParseBool(params.IncludeDeleted)
+		if err != nil {
+			multiError.Append("include_deleted", fmt.Errorf("include_deleted must be boolean"))
+		}
+		articleParams.IncludeDeleted = includeDeleted
+	}
+
+	var err error
+	if articleParams.CreatedFrom, err = parseDateParam(params.CreatedFrom, false); err != nil {
+		multiError.Append("created_from", err)
+	}
+
+	if articleParams.CreatedTo, err = parseDateParam(params.CreatedTo, true); err != nil {
+		multiError.Append("created_to", err)
+	}
+
+	if articleParams.ModifiedFrom, err = parseDateParam(params.ModifiedFrom, false); err != nil {
+		multiError.Append("modified_from", err)
+	}
+
+	if articleParams.ModifiedTo, err = parseDateParam(params.ModifiedTo, true); err != nil {
+		multiError.Append("modified_to", err)
+	}
+
+	for _, field := range strings.Split(params.Sort, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+
+		sort := model.SortField{Field: strings.TrimPrefix(field, "-"), Desc: strings.HasPrefix(field, "-")}
+		if !shared.StringInSlice(sort.Field, model.ArticleSortFields) {
+			multiError.Append("sort", fmt.Errorf("sort field %s is not allowed", sort.Field))
+			continue
+		}
+		articleParams.Sort = append(articleParams.Sort, sort)
+	}
+
+	if isCursor {
+		cursor, err := model.DecodeArticleCursor(params.Cursor)
+		if err != nil {
+			multiError.Append("cursor", err)
+		}
+		articleParams.Cursor = cursor
+
+		// keyset pagination always seeks on (created, id) with newest first
+		if params.PageNumber != "" {
+			multiError.Append("page", fmt.Errorf("page can not be combined with cursor"))
+		}
+
+		if params.Sort != "" {
+			multiError.Append("sort", fmt.Errorf("sort can not be combined with cursor"))
+		}
+	}
+
+	if len(articleParams.Sort) == 0 {
+		articleParams.Sort = []model.SortField{{Field: "created", Desc: true}}
+	}
+
+	return articleParams, multiError
+}
+
+// parseFields function for parsing comma separated fields query param into list of ArticleFields,
+// invalid value is appended into multiError
+func parseFields(value string, multiError *shared.MultiError) []string {
+	var fields []string
+	for _, field := range strings.Split(value, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" || shared.StringInSlice(field, fields) {
+			continue
+		}
+
+		if !shared.StringInSlice(field, model.ArticleFields) {
+			multiError.Append("fields", fmt.Errorf("field %s is not allowed", field))
+			continue
+		}
+		fields = append(fields, field)
+	}
+
+	return fields
+}
+
+// parseInclude function for parsing comma separated include query param into list of ArticleIncludes,
+// invalid value is appended into multiError
+func parseInclude(value string, multiError *shared.MultiError) []string {
+	var include []string
+	for _, relation := range strings.Split(value, ",") {
+		relation = strings.TrimSpace(relation)
+		if relation == "" {
+			continue
+		}
+
+		if !shared.StringInSlice(relation, model.ArticleIncludes) {
+			multiError.Append("include", fmt.Errorf("include %s is not allowed", relation))
+			continue
+		}
+		include = append(include, relation)
+	}
+
+	return include
+}
+
+// parseDateParam function for parsing date query param in RFC3339 or YYYY-MM-DD format,
+// date only value of upper bound is moved into the end of that day
+func parseDateParam(value string, isUpperBound bool) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, fmt.Errorf("date must be in RFC3339 or YYYY-MM-DD format")
+	}
+
+	if isUpperBound {
+		t = t.Add(24*time.Hour - time.Nanosecond)
+	}
+
+	return &t, nil
+}
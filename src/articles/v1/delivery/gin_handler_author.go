@@ -0,0 +1,83 @@
+package delivery
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/willy182/boilerplate-go-cleanarch/src/articles/v1/model"
+	"github.com/willy182/boilerplate-go-cleanarch/src/shared"
+	"github.com/willy182/boilerplate-go-cleanarch/utils"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+)
+
+// GetAllByAuthor method for handling route article list of author by author ID
+func (h *ArticleHandler) GetAllByAuthor(c *gin.Context) {
+	ctxHandler := "article_handler_get_all_by_author"
+	ctx := c.Request.Context()
+	multiError := shared.NewMultiError()
+
+	authorID, ok := h.validateID(c, ctxHandler)
+	if !ok {
+		return
+	}
+
+	var params model.ArticleRequestParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		multiError.Append("error", err)
+		utils.Log(log.ErrorLevel, multiError.Error(), ctxHandler, "bind_params")
+		response := shared.NewHTTPResponse(http.StatusBadRequest, "bind params", multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	articleParams, multiError := buildArticleParams(params, false)
+	if params.Cursor != "" {
+		multiError.Append("cursor", fmt.Errorf("cursor is not supported on author article list"))
+	}
+
+	if multiError.HasError() {
+		utils.Log(log.ErrorLevel, multiError.Error(), ctxHandler, "validate_params")
+		response := shared.NewHTTPResponse(http.StatusBadRequest, "validate params", multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	if articleParams.IncludeDeleted && !shared.CallerFromContext(ctx).IsAdmin() {
+		multiError.Append("include_deleted", fmt.Errorf("include_deleted is only allowed for admin"))
+		utils.Log(log.ErrorLevel, multiError.Error(), ctxHandler, "validate_caller")
+		response := shared.NewHTTPResponse(http.StatusForbidden, "validate caller", multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	locales, ok := h.negotiateLocales(c, ctxHandler)
+	if !ok {
+		return
+	}
+
+	res := <-h.ArticleUseCase.GetAllByAuthor(ctx, authorID, articleParams)
+	if res.Error != nil {
+		utils.Log(log.ErrorLevel, res.Error.Error(), ctxHandler, "err_res_get_all_by_author")
+		response := shared.NewHTTPResponse(errorStatusCode(res.Error), res.Error.Error(), multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	result := res.Result.(model.ArticleList)
+	articles, ok := h.includeArticles(c, ctxHandler, result.Data, articleParams.Include)
+	if !ok {
+		return
+	}
+
+	articles, ok = h.translateArticles(c, ctxHandler, articles, locales)
+	if !ok {
+		return
+	}
+
+	data := articleListData(articles, articleParams.Fields, articleParams.Include)
+	meta := shared.CreateMeta(result.Total, articleParams.Page, articleParams.Limit)
+	response := shared.NewHTTPResponse(http.StatusOK, "Author Article List", data, meta)
+	response.JSON(c.Writer)
+}
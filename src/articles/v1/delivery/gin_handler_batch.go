@@ -0,0 +1,134 @@
+package delivery
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/willy182/boilerplate-go-cleanarch/src/articles/v1/model"
+	"github.com/willy182/boilerplate-go-cleanarch/src/shared"
+	"github.com/willy182/boilerplate-go-cleanarch/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	log "github.com/sirupsen/logrus"
+)
+
+// Batch method for handling route apply create, update, delete and status operations of articles in single transaction,
+// errors of failed operations are keyed by their index
+func (h *ArticleHandler) Batch(c *gin.Context) {
+	ctxHandler := "article_handler_batch"
+	ctx := c.Request.Context()
+	multiError := shared.NewMultiError()
+
+	var param model.ArticleBatchRequest
+	if err := c.ShouldBindJSON(&param); err != nil {
+		multiError.Append("error", err)
+		utils.Log(log.ErrorLevel, multiError.Error(), ctxHandler, "bind_payload")
+		response := shared.NewHTTPResponse(http.StatusBadRequest, "bind payload", multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	if param.Mode == "" {
+		param.Mode = model.BatchModeAtomic
+	}
+
+	if !shared.StringInSlice(param.Mode, model.BatchModes) {
+		multiError.Append("mode", fmt.Errorf("mode must be one of %s", strings.Join(model.BatchModes, ", ")))
+		utils.Log(log.ErrorLevel, multiError.Error(), ctxHandler, "validate_mode")
+		response := shared.NewHTTPResponse(http.StatusBadRequest, "validate mode", multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	// invalid operation is reported with the others instead of rejecting the whole batch
+	for i := range param.Operations {
+		param.Operations[i].Err = validateBatchOperation(&param.Operations[i])
+	}
+
+	res := <-h.ArticleUseCase.Batch(ctx, param)
+	if res.Error != nil {
+		utils.Log(log.ErrorLevel, res.Error.Error(), ctxHandler, "err_res_batch")
+		response := shared.NewHTTPResponse(errorStatusCode(res.Error), res.Error.Error(), multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	results := res.Result.([]model.ArticleBatchResult)
+	for _, result := range results {
+		multiError.Append(strconv.Itoa(result.Index), result.Err)
+	}
+
+	if !multiError.HasError() {
+		response := shared.NewHTTPResponse(http.StatusOK, "Article Batch Applied", results)
+		response.JSON(c.Writer)
+		return
+	}
+
+	message := "Article Batch With Failed Operations"
+	if param.Mode == model.BatchModeAtomic {
+		message = "Article Batch Rolled Back"
+	}
+
+	utils.Log(log.ErrorLevel, multiError.Error(), ctxHandler, "batch_failed_operations")
+	response := shared.NewHTTPResponse(http.StatusUnprocessableEntity, message, results, multiError)
+	response.JSON(c.Writer)
+}
+
+// validateBatchOperation function for validating fields that are required by action of batch operation
+func validateBatchOperation(op *model.ArticleBatchOperation) error {
+	if !shared.StringInSlice(op.Action, model.BatchActions) {
+		return fmt.Errorf("action must be one of %s", strings.Join(model.BatchActions, ", "))
+	}
+
+	if op.Action == model.BatchCreate && op.ID != 0 {
+		return fmt.Errorf("id must be empty on %s", op.Action)
+	}
+
+	if op.Action != model.BatchCreate && op.ID <= 0 {
+		return fmt.Errorf("id is required on %s", op.Action)
+	}
+
+	if (op.Action == model.BatchUpdate || op.Action == model.BatchStatus) && op.Version <= 0 {
+		return fmt.Errorf("version is required on %s", op.Action)
+	}
+
+	if op.Action == model.BatchStatus && !shared.StringInSlice(op.Status, model.ArticleStatuses) {
+		return fmt.Errorf("status %s is not valid", op.Status)
+	}
+
+	if op.Action == model.BatchDelete || op.Action == model.BatchStatus {
+		if len(op.Tags) > 0 || len(op.Categories) > 0 {
+			return fmt.Errorf("tags and categories can only be attached on %s and %s", model.BatchCreate, model.BatchUpdate)
+		}
+		return nil
+	}
+
+	if op.Article == nil {
+		return fmt.Errorf("article is required on %s", op.Action)
+	}
+
+	if err := binding.Validator.ValidateStruct(op.Article); err != nil {
+		return err
+	}
+
+	if op.Article.Slug != "" {
+		if err := shared.ValidateSlug(op.Article.Slug); err != nil {
+			return err
+		}
+	}
+
+	for _, names := range [][]string{op.Tags, op.Categories} {
+		if len(names) == 0 {
+			continue
+		}
+
+		if err := binding.Validator.ValidateStruct(model.TaxonomyRequest{Names: names}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,170 @@
+package delivery
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/willy182/boilerplate-go-cleanarch/src/articles/v1/model"
+	"github.com/willy182/boilerplate-go-cleanarch/src/shared"
+	"github.com/willy182/boilerplate-go-cleanarch/utils"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+)
+
+// FeedRSS method for handling route RSS 2.0 feed of latest published articles, optionally by tag or author
+func (h *ArticleHandler) FeedRSS(c *gin.Context) {
+	h.writeFeed(c, "article_handler_feed_rss", model.FeedRSS)
+}
+
+// FeedAtom method for handling route Atom 1.0 feed of latest published articles, optionally by tag or author
+func (h *ArticleHandler) FeedAtom(c *gin.Context) {
+	h.writeFeed(c, "article_handler_feed_atom", model.FeedAtom)
+}
+
+// writeFeed function for writing feed of format narrowed by tag or author id route param
+func (h *ArticleHandler) writeFeed(c *gin.Context, ctxHandler string, format string) {
+	ctx := c.Request.Context()
+	multiError := shared.NewMultiError()
+
+	var params model.ArticleFeedParams
+	if tag := c.Param("tag"); tag != "" {
+		if !h.validateSlug(c, ctxHandler, tag) {
+			return
+		}
+		params.Tag = tag
+	}
+
+	if c.Param("id") != "" {
+		authorID, ok := h.validateID(c, ctxHandler)
+		if !ok {
+			return
+		}
+		params.AuthorID = authorID
+	}
+
+	res := <-h.ArticleUseCase.GetFeed(ctx, params)
+	if res.Error != nil {
+		utils.Log(log.ErrorLevel, res.Error.Error(), ctxHandler, "err_res_get_feed")
+		response := shared.NewHTTPResponse(errorStatusCode(res.Error), res.Error.Error(), multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	result := res.Result.(model.ArticleFeed)
+	host := shared.GetHostURL(c.Request)
+
+	// links are absolute so the tag also depends on the host the feed is requested from,
+	// Last-Modified is taken from every article of the feed scope instead of the items only,
+	// so it still moves forward when an item leaves the feed, author of an item changes without touching the article
+	etagParts := []interface{}{format, host, c.Request.URL.Path, result.Title}
+	lastModified := result.LastModified
+	for _, article := range result.Articles {
+		etagParts = append(etagParts, article.ID, article.Version, article.Modified)
+		if article.Author == nil {
+			continue
+		}
+		etagParts = append(etagParts, article.Author.ID, article.Author.Modified)
+
+		if modified, err := time.Parse(time.RFC3339, article.Author.Modified); err == nil && modified.After(lastModified) {
+			lastModified = modified
+		}
+	}
+
+	etag := shared.GenerateETag(etagParts...)
+	shared.SetCacheHeaders(c.Writer, etag, lastModified)
+	if shared.IsNotModified(c.Request, etag, lastModified) {
+		c.AbortWithStatus(http.StatusNotModified)
+		return
+	}
+
+	feed := buildFeed(host, c.Request.URL.Path, h.Link, params, result)
+	feed.Updated = lastModified
+	if lastModified.IsZero() {
+		// empty feed has no entry to be dated by, epoch keeps the document stable for its tag
+		feed.Updated = time.Unix(0, 0)
+	}
+
+	var (
+		body []byte
+		err  error
+	)
+
+	contentType := shared.RSSContentType
+	if format == model.FeedAtom {
+		body, err = feed.Atom()
+		contentType = shared.AtomContentType
+	} else {
+		body, err = feed.RSS()
+	}
+
+	if err != nil {
+		utils.Log(log.ErrorLevel, err.Error(), ctxHandler, "encode_feed")
+		response := shared.NewHTTPResponse(http.StatusInternalServerError, err.Error(), multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	c.Data(http.StatusOK, contentType, body)
+}
+
+// buildFeed function for converting articles of feed into syndication feed with absolute links on host,
+// item links to its article by articleLink
+func buildFeed(host, path string, articleLink model.ArticleLink, params model.ArticleFeedParams, result model.ArticleFeed) shared.Feed {
+	link := host + "/v1/articles"
+	switch {
+	case params.AuthorID > 0:
+		link = fmt.Sprintf("%s/v1/authors/%d/articles", host, params.AuthorID)
+	case params.Tag != "":
+		link += "?tag=" + params.Tag
+	}
+
+	feed := shared.Feed{
+		Title:       result.Title,
+		Link:        link,
+		SelfLink:    host + path,
+		Description: result.Title,
+		Items:       make([]shared.FeedItem, 0, len(result.Articles)),
+	}
+
+	for _, article := range result.Articles {
+		formatDescription(&article, model.FormatHTML)
+
+		item := shared.FeedItem{
+			ID:      host + "/v1/article/" + strconv.Itoa(article.ID),
+			Title:   article.Title,
+			Link:    articleLink.URL(host, article.ID, article.Slug),
+			Summary: article.Summary,
+			Content: article.Description,
+			Image:   article.Image,
+			Updated: article.LastModified(),
+		}
+
+		if strings.HasPrefix(item.Image, "/") {
+			item.Image = host + item.Image
+		}
+
+		if publishedAt, err := time.Parse(time.RFC3339, article.PublishedAt); err == nil {
+			item.Published = publishedAt
+		}
+
+		if article.Author != nil {
+			item.Author = article.Author.Name
+		}
+
+		for _, tag := range article.Tags {
+			item.Categories = append(item.Categories, tag.Name)
+		}
+
+		for _, category := range article.Categories {
+			item.Categories = append(item.Categories, category.Name)
+		}
+
+		feed.Items = append(feed.Items, item)
+	}
+
+	return feed
+}
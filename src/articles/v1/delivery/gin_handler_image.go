@@ -0,0 +1,77 @@
+package delivery
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/willy182/boilerplate-go-cleanarch/src/articles/v1/model"
+	"github.com/willy182/boilerplate-go-cleanarch/src/shared"
+	"github.com/willy182/boilerplate-go-cleanarch/utils"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+)
+
+// imageRequestOverhead room for multipart boundaries and other form fields on top of model.ImageMaxSize
+const imageRequestOverhead = 1 << 20
+
+// UploadImage method for handling route upload image of article by ID as multipart form
+func (h *ArticleHandler) UploadImage(c *gin.Context) {
+	ctxHandler := "article_handler_upload_image"
+	ctx := c.Request.Context()
+	multiError := shared.NewMultiError()
+
+	id, ok := h.validateID(c, ctxHandler)
+	if !ok {
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, model.ImageMaxSize+imageRequestOverhead)
+
+	file, header, err := c.Request.FormFile(model.ImageFormField)
+	if err != nil {
+		code := http.StatusBadRequest
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			code, err = http.StatusRequestEntityTooLarge, model.ErrImageTooLarge
+		}
+
+		multiError.Append(model.ImageFormField, err)
+		utils.Log(log.ErrorLevel, multiError.Error(), ctxHandler, "form_file")
+		response := shared.NewHTTPResponse(code, "bind payload", multiError)
+		response.JSON(c.Writer)
+		return
+	}
+	defer file.Close()
+
+	if header.Size > model.ImageMaxSize {
+		multiError.Append(model.ImageFormField, model.ErrImageTooLarge)
+		response := shared.NewHTTPResponse(http.StatusRequestEntityTooLarge, "bind payload", multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	data, err := ioutil.ReadAll(file)
+	if err != nil {
+		multiError.Append(model.ImageFormField, err)
+		utils.Log(log.ErrorLevel, multiError.Error(), ctxHandler, "read_file")
+		response := shared.NewHTTPResponse(http.StatusBadRequest, "bind payload", multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	param := model.ArticleImageUpload{Data: data}
+
+	res := <-h.ArticleUseCase.UploadImage(ctx, id, param)
+	if res.Error != nil {
+		utils.Log(log.ErrorLevel, res.Error.Error(), ctxHandler, "err_res_upload_image")
+		response := shared.NewHTTPResponse(errorStatusCode(res.Error), res.Error.Error(), multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	result := res.Result.(model.Article)
+	response := shared.NewHTTPResponse(http.StatusOK, "Article Image Uploaded", result)
+	response.JSON(c.Writer)
+}
@@ -0,0 +1,83 @@
+package delivery
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/willy182/boilerplate-go-cleanarch/src/articles/v1/model"
+	"github.com/willy182/boilerplate-go-cleanarch/src/shared"
+	"github.com/willy182/boilerplate-go-cleanarch/utils"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+)
+
+// GetPopular method for handling route published articles with the most views in window,
+// window is rounded down to whole days as described by model.PopularWindows
+func (h *ArticleHandler) GetPopular(c *gin.Context) {
+	ctxHandler := "article_handler_get_popular"
+	ctx := c.Request.Context()
+	multiError := shared.NewMultiError()
+
+	params := model.PopularParams{
+		Window: c.DefaultQuery("window", model.PopularWindowNames[0]),
+		Limit:  model.PopularDefaultLimit,
+	}
+
+	if _, ok := model.PopularWindows[params.Window]; !ok {
+		multiError.Append("window", fmt.Errorf("window must be one of %s", strings.Join(model.PopularWindowNames, ", ")))
+	}
+
+	if value := c.Query("limit"); value != "" {
+		var err error
+		if params.Limit, err = strconv.Atoi(value); err != nil || params.Limit < 1 || params.Limit > model.PopularMaxLimit {
+			multiError.Append("limit", fmt.Errorf("limit must be number between 1 and %d", model.PopularMaxLimit))
+		}
+	}
+	include := parseInclude(c.Query("include"), multiError)
+
+	if multiError.HasError() {
+		utils.Log(log.ErrorLevel, multiError.Error(), ctxHandler, "validate_params")
+		response := shared.NewHTTPResponse(http.StatusBadRequest, "validate params", multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	locales, ok := h.negotiateLocales(c, ctxHandler)
+	if !ok {
+		return
+	}
+
+	res := <-h.ArticleUseCase.GetPopular(ctx, params)
+	if res.Error != nil {
+		utils.Log(log.ErrorLevel, res.Error.Error(), ctxHandler, "err_res_get_popular")
+		response := shared.NewHTTPResponse(errorStatusCode(res.Error), res.Error.Error(), multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	result := res.Result.([]model.PopularArticle)
+	articles := make([]model.Article, 0, len(result))
+	for _, popular := range result {
+		articles = append(articles, popular.Article)
+	}
+
+	articles, ok = h.includeArticles(c, ctxHandler, articles, include)
+	if !ok {
+		return
+	}
+
+	articles, ok = h.translateArticles(c, ctxHandler, articles, locales)
+	if !ok {
+		return
+	}
+
+	for i := range result {
+		result[i].Article = articles[i]
+	}
+
+	response := shared.NewHTTPResponse(http.StatusOK, "Popular Article List", result)
+	response.JSON(c.Writer)
+}
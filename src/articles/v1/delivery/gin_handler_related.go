@@ -0,0 +1,68 @@
+package delivery
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/willy182/boilerplate-go-cleanarch/src/articles/v1/model"
+	"github.com/willy182/boilerplate-go-cleanarch/src/shared"
+	"github.com/willy182/boilerplate-go-cleanarch/utils"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+)
+
+// GetRelated method for handling route published articles related to article by ID
+func (h *ArticleHandler) GetRelated(c *gin.Context) {
+	ctxHandler := "article_handler_get_related"
+	ctx := c.Request.Context()
+	multiError := shared.NewMultiError()
+
+	id, ok := h.validateID(c, ctxHandler)
+	if !ok {
+		return
+	}
+
+	limit := model.RelatedDefaultLimit
+	if value := c.Query("limit"); value != "" {
+		var err error
+		if limit, err = strconv.Atoi(value); err != nil || limit < 1 || limit > model.RelatedMaxLimit {
+			multiError.Append("limit", fmt.Errorf("limit must be number between 1 and %d", model.RelatedMaxLimit))
+		}
+	}
+	include := parseInclude(c.Query("include"), multiError)
+
+	if multiError.HasError() {
+		utils.Log(log.ErrorLevel, multiError.Error(), ctxHandler, "validate_params")
+		response := shared.NewHTTPResponse(http.StatusBadRequest, "validate params", multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	locales, ok := h.negotiateLocales(c, ctxHandler)
+	if !ok {
+		return
+	}
+
+	res := <-h.ArticleUseCase.GetRelated(ctx, id, limit)
+	if res.Error != nil {
+		utils.Log(log.ErrorLevel, res.Error.Error(), ctxHandler, "err_res_get_related")
+		response := shared.NewHTTPResponse(errorStatusCode(res.Error), res.Error.Error(), multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	articles, ok := h.includeArticles(c, ctxHandler, res.Result.([]model.Article), include)
+	if !ok {
+		return
+	}
+
+	articles, ok = h.translateArticles(c, ctxHandler, articles, locales)
+	if !ok {
+		return
+	}
+
+	response := shared.NewHTTPResponse(http.StatusOK, "Related Article List", articles)
+	response.JSON(c.Writer)
+}
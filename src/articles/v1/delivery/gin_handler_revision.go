@@ -0,0 +1,144 @@
+package delivery
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/willy182/boilerplate-go-cleanarch/src/articles/v1/model"
+	"github.com/willy182/boilerplate-go-cleanarch/src/shared"
+	"github.com/willy182/boilerplate-go-cleanarch/utils"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+)
+
+// GetRevisions method for handling route revision history of article
+func (h *ArticleHandler) GetRevisions(c *gin.Context) {
+	ctxHandler := "article_handler_get_revisions"
+	ctx := c.Request.Context()
+	multiError := shared.NewMultiError()
+
+	id, ok := h.validateID(c, ctxHandler)
+	if !ok {
+		return
+	}
+
+	res := <-h.ArticleUseCase.GetRevisions(ctx, id)
+	if res.Error != nil {
+		utils.Log(log.ErrorLevel, res.Error.Error(), ctxHandler, "err_res_get_revisions")
+		response := shared.NewHTTPResponse(errorStatusCode(res.Error), res.Error.Error(), multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	result := res.Result.([]model.ArticleRevision)
+	response := shared.NewHTTPResponse(http.StatusOK, "Article Revision List", result)
+	response.JSON(c.Writer)
+}
+
+// GetRevision method for handling route single revision of article
+func (h *ArticleHandler) GetRevision(c *gin.Context) {
+	ctxHandler := "article_handler_get_revision"
+	ctx := c.Request.Context()
+	multiError := shared.NewMultiError()
+
+	id, ok := h.validateID(c, ctxHandler)
+	if !ok {
+		return
+	}
+
+	rev, ok := h.validateNumericParam(c, ctxHandler, "rev")
+	if !ok {
+		return
+	}
+
+	res := <-h.ArticleUseCase.GetRevision(ctx, id, rev)
+	if res.Error != nil {
+		utils.Log(log.ErrorLevel, res.Error.Error(), ctxHandler, "err_res_get_revision")
+		response := shared.NewHTTPResponse(errorStatusCode(res.Error), res.Error.Error(), multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	result := res.Result.(model.ArticleRevision)
+	response := shared.NewHTTPResponse(http.StatusOK, "Article Revision", result)
+	response.JSON(c.Writer)
+}
+
+// DiffRevisions method for handling route field level difference between two revisions of article
+func (h *ArticleHandler) DiffRevisions(c *gin.Context) {
+	ctxHandler := "article_handler_diff_revisions"
+	ctx := c.Request.Context()
+	multiError := shared.NewMultiError()
+
+	id, ok := h.validateID(c, ctxHandler)
+	if !ok {
+		return
+	}
+
+	from, ok := h.validateNumericParam(c, ctxHandler, "rev")
+	if !ok {
+		return
+	}
+
+	to, ok := h.validateNumericParam(c, ctxHandler, "to")
+	if !ok {
+		return
+	}
+
+	res := <-h.ArticleUseCase.DiffRevisions(ctx, id, from, to)
+	if res.Error != nil {
+		utils.Log(log.ErrorLevel, res.Error.Error(), ctxHandler, "err_res_diff_revisions")
+		response := shared.NewHTTPResponse(errorStatusCode(res.Error), res.Error.Error(), multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	result := res.Result.(model.ArticleRevisionDiff)
+	response := shared.NewHTTPResponse(http.StatusOK, "Article Revision Diff", result)
+	response.JSON(c.Writer)
+}
+
+// RestoreRevision method for handling route rollback article into one of its revision
+func (h *ArticleHandler) RestoreRevision(c *gin.Context) {
+	ctxHandler := "article_handler_restore_revision"
+	ctx := c.Request.Context()
+	multiError := shared.NewMultiError()
+
+	id, ok := h.validateID(c, ctxHandler)
+	if !ok {
+		return
+	}
+
+	rev, ok := h.validateNumericParam(c, ctxHandler, "rev")
+	if !ok {
+		return
+	}
+
+	// payload is optional since version can be sent in If-Match header
+	var param model.ArticleVersionRequest
+	if err := c.ShouldBindJSON(&param); err != nil && err != io.EOF {
+		multiError.Append("error", err)
+		utils.Log(log.ErrorLevel, multiError.Error(), ctxHandler, "bind_payload")
+		response := shared.NewHTTPResponse(http.StatusBadRequest, "bind payload", multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	version, ok := h.validateVersion(c, ctxHandler, param.Version)
+	if !ok {
+		return
+	}
+
+	res := <-h.ArticleUseCase.RestoreRevision(ctx, id, rev, version)
+	if res.Error != nil {
+		utils.Log(log.ErrorLevel, res.Error.Error(), ctxHandler, "err_res_restore_revision")
+		response := shared.NewHTTPResponse(errorStatusCode(res.Error), res.Error.Error(), multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	result := res.Result.(model.Article)
+	response := shared.NewHTTPResponse(http.StatusOK, "Article Revision Restored", result)
+	response.JSON(c.Writer)
+}
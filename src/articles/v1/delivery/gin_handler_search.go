@@ -0,0 +1,68 @@
+package delivery
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/willy182/boilerplate-go-cleanarch/src/articles/v1/model"
+	"github.com/willy182/boilerplate-go-cleanarch/src/shared"
+	"github.com/willy182/boilerplate-go-cleanarch/utils"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+)
+
+// Search method for handling route full text search of article
+func (h *ArticleHandler) Search(c *gin.Context) {
+	ctxHandler := "article_handler_search"
+	ctx := c.Request.Context()
+	multiError := shared.NewMultiError()
+
+	var params model.ArticleSearchRequestParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		multiError.Append("error", err)
+		utils.Log(log.ErrorLevel, multiError.Error(), ctxHandler, "bind_params")
+		response := shared.NewHTTPResponse(http.StatusBadRequest, "bind params", multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	articleParams := model.ArticleParams{
+		Query:    strings.TrimSpace(params.Query),
+		Language: params.Language,
+	}
+	articleParams.Page, articleParams.Limit = shared.ParsePagination(params.PageNumber, params.PageSize, multiError)
+
+	if articleParams.Query == "" {
+		multiError.Append("q", fmt.Errorf("q is required"))
+	}
+
+	if articleParams.Language == "" {
+		articleParams.Language = model.SearchLanguageSimple
+	}
+
+	if !shared.StringInSlice(articleParams.Language, model.SearchLanguages) {
+		multiError.Append("lang", fmt.Errorf("lang must be one of %s", strings.Join(model.SearchLanguages, ", ")))
+	}
+
+	if multiError.HasError() {
+		utils.Log(log.ErrorLevel, multiError.Error(), ctxHandler, "validate_params")
+		response := shared.NewHTTPResponse(http.StatusBadRequest, "validate params", multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	res := <-h.ArticleUseCase.Search(ctx, articleParams)
+	if res.Error != nil {
+		utils.Log(log.ErrorLevel, res.Error.Error(), ctxHandler, "err_res_search")
+		response := shared.NewHTTPResponse(errorStatusCode(res.Error), res.Error.Error(), multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	result := res.Result.(model.ArticleSearchList)
+	meta := shared.CreateMeta(result.Total, articleParams.Page, articleParams.Limit)
+	response := shared.NewHTTPResponse(http.StatusOK, "Article Search", result.Data, meta)
+	response.JSON(c.Writer)
+}
@@ -0,0 +1,99 @@
+package delivery
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/willy182/boilerplate-go-cleanarch/src/articles/v1/model"
+	"github.com/willy182/boilerplate-go-cleanarch/src/shared"
+	"github.com/willy182/boilerplate-go-cleanarch/utils"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+)
+
+// MountSitemap function for mounting sitemap routes, sitemap is only valid for urls under its own location
+// so it must be mounted on the site root, article urls are built by Link
+func (h *ArticleHandler) MountSitemap(group *gin.RouterGroup) {
+	group.GET("/sitemap.xml", h.Sitemap)
+	group.GET("/sitemaps/articles/:page", h.SitemapPage)
+}
+
+// Sitemap method for handling route root sitemap of published articles,
+// it becomes sitemap index when articles do not fit into a single sitemap
+func (h *ArticleHandler) Sitemap(c *gin.Context) {
+	h.writeSitemap(c, "article_handler_sitemap", model.SitemapRoot)
+}
+
+// SitemapPage method for handling route sitemap page listed by sitemap index
+func (h *ArticleHandler) SitemapPage(c *gin.Context) {
+	ctxHandler := "article_handler_sitemap_page"
+	multiError := shared.NewMultiError()
+
+	param := c.Param("page")
+	after, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(param, "after-"), ".xml"))
+	if err != nil || after < 0 || !strings.HasPrefix(param, "after-") || !strings.HasSuffix(param, ".xml") {
+		multiError.Append("page", fmt.Errorf("page must be after- followed by article id with .xml extension"))
+		utils.Log(log.ErrorLevel, multiError.Error(), ctxHandler, "validate_page")
+		response := shared.NewHTTPResponse(http.StatusNotFound, "validate page", multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	h.writeSitemap(c, ctxHandler, after)
+}
+
+// writeSitemap function for writing sitemap page of articles after id, model.SitemapRoot is the root sitemap
+func (h *ArticleHandler) writeSitemap(c *gin.Context, ctxHandler string, after int) {
+	ctx := c.Request.Context()
+	multiError := shared.NewMultiError()
+
+	res := <-h.ArticleUseCase.GetSitemap(ctx, after)
+	if res.Error != nil {
+		utils.Log(log.ErrorLevel, res.Error.Error(), ctxHandler, "err_res_get_sitemap")
+		response := shared.NewHTTPResponse(errorStatusCode(res.Error), res.Error.Error(), multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	sitemap := res.Result.(model.Sitemap)
+	body, err := SitemapXML(shared.GetHostURL(c.Request), h.Link, sitemap)
+	if err != nil {
+		utils.Log(log.ErrorLevel, err.Error(), ctxHandler, "encode_sitemap")
+		response := shared.NewHTTPResponse(http.StatusInternalServerError, err.Error(), multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	etag := shared.GenerateETag(string(body))
+	lastModified := sitemap.LastModified()
+	shared.SetCacheHeaders(c.Writer, etag, lastModified)
+	if shared.IsNotModified(c.Request, etag, lastModified) {
+		c.AbortWithStatus(http.StatusNotModified)
+		return
+	}
+
+	c.Data(http.StatusOK, shared.SitemapContentType, body)
+}
+
+// SitemapXML function for encoding sitemap into sitemap or sitemap index document, sitemap pages are on baseURL
+// and articles are linked by link
+func SitemapXML(baseURL string, link model.ArticleLink, sitemap model.Sitemap) ([]byte, error) {
+	baseURL = strings.TrimRight(baseURL, "/")
+
+	if len(sitemap.Index) > 0 {
+		sitemaps := make([]shared.SitemapURL, 0, len(sitemap.Index))
+		for _, page := range sitemap.Index {
+			sitemaps = append(sitemaps, shared.SitemapURL{Loc: baseURL + page.Path(), LastModified: page.Modified})
+		}
+		return shared.EncodeSitemapIndex(sitemaps)
+	}
+
+	urls := make([]shared.SitemapURL, 0, len(sitemap.Entries))
+	for _, entry := range sitemap.Entries {
+		urls = append(urls, shared.SitemapURL{Loc: link.URL(baseURL, entry.ID, entry.Slug), LastModified: entry.Modified})
+	}
+	return shared.EncodeSitemap(urls)
+}
@@ -0,0 +1,55 @@
+package delivery
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/willy182/boilerplate-go-cleanarch/src/articles/v1/model"
+	"github.com/willy182/boilerplate-go-cleanarch/src/shared"
+	"github.com/willy182/boilerplate-go-cleanarch/utils"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+)
+
+// GetBySlug method for handling route article by slug,
+// old slug is permanently redirected into the current slug of article
+func (h *ArticleHandler) GetBySlug(c *gin.Context) {
+	ctxHandler := "article_handler_get_by_slug"
+	ctx := c.Request.Context()
+	multiError := shared.NewMultiError()
+
+	slug := c.Param("slug")
+	if !h.validateSlug(c, ctxHandler, slug) {
+		return
+	}
+
+	fields, include, format, ok := h.validateRepresentation(c, ctxHandler)
+	if !ok {
+		return
+	}
+
+	locales, ok := h.negotiateLocales(c, ctxHandler)
+	if !ok {
+		return
+	}
+
+	res := <-h.ArticleUseCase.GetBySlug(ctx, slug, fields...)
+	if res.Error != nil {
+		utils.Log(log.ErrorLevel, res.Error.Error(), ctxHandler, "err_res_get_by_slug")
+		response := shared.NewHTTPResponse(errorStatusCode(res.Error), res.Error.Error(), multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	result := res.Result.(model.Article)
+	if result.Slug != slug {
+		location := *c.Request.URL
+		location.Path = strings.TrimSuffix(location.Path, slug) + result.Slug
+		location.RawPath = ""
+		c.Redirect(http.StatusMovedPermanently, location.RequestURI())
+		return
+	}
+
+	h.writeArticle(c, ctxHandler, result, fields, include, locales, format, "Article Get By Slug")
+}
@@ -0,0 +1,136 @@
+package delivery
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/willy182/boilerplate-go-cleanarch/src/articles/v1/model"
+	"github.com/willy182/boilerplate-go-cleanarch/src/articles/v1/usecase"
+	"github.com/willy182/boilerplate-go-cleanarch/src/shared"
+	"github.com/willy182/boilerplate-go-cleanarch/utils"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+)
+
+// GetTags method for handling route tag list with total articles
+func (h *ArticleHandler) GetTags(c *gin.Context) {
+	ctxHandler := "article_handler_get_tags"
+	ctx := c.Request.Context()
+	multiError := shared.NewMultiError()
+
+	res := <-h.ArticleUseCase.GetTags(ctx)
+	if res.Error != nil {
+		utils.Log(log.ErrorLevel, res.Error.Error(), ctxHandler, "err_res_get_tags")
+		response := shared.NewHTTPResponse(errorStatusCode(res.Error), res.Error.Error(), multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	result := res.Result.([]model.TagCount)
+	meta := shared.CreateMeta(len(result), 1, len(result))
+	response := shared.NewHTTPResponse(http.StatusOK, "Tag List", result, meta)
+	response.JSON(c.Writer)
+}
+
+// GetCategories method for handling route category list with total articles
+func (h *ArticleHandler) GetCategories(c *gin.Context) {
+	ctxHandler := "article_handler_get_categories"
+	ctx := c.Request.Context()
+	multiError := shared.NewMultiError()
+
+	res := <-h.ArticleUseCase.GetCategories(ctx)
+	if res.Error != nil {
+		utils.Log(log.ErrorLevel, res.Error.Error(), ctxHandler, "err_res_get_categories")
+		response := shared.NewHTTPResponse(errorStatusCode(res.Error), res.Error.Error(), multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	result := res.Result.([]model.CategoryCount)
+	meta := shared.CreateMeta(len(result), 1, len(result))
+	response := shared.NewHTTPResponse(http.StatusOK, "Category List", result, meta)
+	response.JSON(c.Writer)
+}
+
+// AttachTags method for handling route attach tags into article by ID
+func (h *ArticleHandler) AttachTags(c *gin.Context) {
+	h.attachTaxonomy(c, "article_handler_attach_tags", h.ArticleUseCase.AttachTags, "Article Tags Attached")
+}
+
+// DetachTag method for handling route detach tag from article by ID
+func (h *ArticleHandler) DetachTag(c *gin.Context) {
+	h.detachTaxonomy(c, "article_handler_detach_tag", "tag", h.ArticleUseCase.DetachTag, "Article Tag Detached")
+}
+
+// AttachCategories method for handling route attach categories into article by ID
+func (h *ArticleHandler) AttachCategories(c *gin.Context) {
+	h.attachTaxonomy(c, "article_handler_attach_categories", h.ArticleUseCase.AttachCategories, "Article Categories Attached")
+}
+
+// DetachCategory method for handling route detach category from article by ID
+func (h *ArticleHandler) DetachCategory(c *gin.Context) {
+	h.detachTaxonomy(c, "article_handler_detach_category", "category", h.ArticleUseCase.DetachCategory, "Article Category Detached")
+}
+
+// attachTaxonomy function for handling attach of tags or categories payload into article by ID
+func (h *ArticleHandler) attachTaxonomy(c *gin.Context, ctxHandler string,
+	attach func(context.Context, int, model.TaxonomyRequest) <-chan usecase.ResultUseCase, message string) {
+	ctx := c.Request.Context()
+	multiError := shared.NewMultiError()
+
+	id, ok := h.validateID(c, ctxHandler)
+	if !ok {
+		return
+	}
+
+	var param model.TaxonomyRequest
+	if err := c.ShouldBindJSON(&param); err != nil {
+		multiError.Append("error", err)
+		utils.Log(log.ErrorLevel, multiError.Error(), ctxHandler, "bind_payload")
+		response := shared.NewHTTPResponse(http.StatusBadRequest, "bind payload", multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	res := <-attach(ctx, id, param)
+	if res.Error != nil {
+		utils.Log(log.ErrorLevel, res.Error.Error(), ctxHandler, "err_res_attach")
+		response := shared.NewHTTPResponse(errorStatusCode(res.Error), res.Error.Error(), multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	result := res.Result.(model.Article)
+	response := shared.NewHTTPResponse(http.StatusOK, message, result)
+	response.JSON(c.Writer)
+}
+
+// detachTaxonomy function for handling detach of tag or category named by slug route param from article by ID
+func (h *ArticleHandler) detachTaxonomy(c *gin.Context, ctxHandler string, param string,
+	detach func(context.Context, int, string) <-chan usecase.ResultUseCase, message string) {
+	ctx := c.Request.Context()
+	multiError := shared.NewMultiError()
+
+	id, ok := h.validateID(c, ctxHandler)
+	if !ok {
+		return
+	}
+
+	slug := c.Param(param)
+	if !h.validateSlug(c, ctxHandler, slug) {
+		return
+	}
+
+	res := <-detach(ctx, id, slug)
+	if res.Error != nil {
+		utils.Log(log.ErrorLevel, res.Error.Error(), ctxHandler, "err_res_detach")
+		response := shared.NewHTTPResponse(errorStatusCode(res.Error), res.Error.Error(), multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	result := res.Result.(model.Article)
+	response := shared.NewHTTPResponse(http.StatusOK, message, result)
+	response.JSON(c.Writer)
+}
@@ -0,0 +1,414 @@
+package delivery
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/willy182/boilerplate-go-cleanarch/src/articles/v1/model"
+	"github.com/willy182/boilerplate-go-cleanarch/src/shared"
+	"github.com/willy182/boilerplate-go-cleanarch/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// ndjsonMaxLineSize maximum size of single article line of ndjson import
+	ndjsonMaxLineSize = 8 << 20
+	// csvContentType content type of csv import and export
+	csvContentType = "text/csv"
+	// ndjsonContentType content type of ndjson import and export
+	ndjsonContentType = "application/x-ndjson"
+)
+
+// transferContentTypes mapping of request content type into import format
+var transferContentTypes = map[string]string{
+	csvContentType:       model.TransferCSV,
+	ndjsonContentType:    model.TransferNDJSON,
+	"application/ndjson": model.TransferNDJSON,
+	"application/jsonl":  model.TransferNDJSON,
+}
+
+// Import method for handling route import articles from csv or ndjson body, rows are read and saved one by one,
+// format is taken from format query param or content type and dry_run=true only validates the rows
+func (h *ArticleHandler) Import(c *gin.Context) {
+	ctxHandler := "article_handler_import"
+	ctx := c.Request.Context()
+	multiError := shared.NewMultiError()
+
+	format := c.Query("format")
+	if format == "" {
+		mediaType, _, _ := mime.ParseMediaType(c.GetHeader("Content-Type"))
+		format = transferContentTypes[mediaType]
+	}
+
+	if !shared.StringInSlice(format, model.TransferFormats) {
+		multiError.Append("format", fmt.Errorf("format must be one of %s, send it in query param or Content-Type header",
+			strings.Join(model.TransferFormats, ", ")))
+	}
+
+	var dryRun bool
+	if value := c.Query("dry_run"); value != "" {
+		var err error
+		if dryRun, err = strconv.ParseBool(value); err != nil {
+			multiError.Append("dry_run", fmt.Errorf("dry_run must be boolean"))
+		}
+	}
+
+	if multiError.HasError() {
+		utils.Log(log.ErrorLevel, multiError.Error(), ctxHandler, "validate_params")
+		response := shared.NewHTTPResponse(http.StatusBadRequest, "validate params", multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	rows := make(chan model.ArticleImportRow)
+	go readImportRows(ctx, c.Request.Body, format, rows)
+
+	res := <-h.ArticleUseCase.Import(ctx, rows, dryRun)
+	if res.Error != nil {
+		utils.Log(log.ErrorLevel, res.Error.Error(), ctxHandler, "err_res_import")
+		response := shared.NewHTTPResponse(errorStatusCode(res.Error), res.Error.Error(), multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	result := res.Result.(model.ArticleImportResult)
+	message := "Article Imported"
+	if dryRun {
+		message = "Article Import Validated"
+	}
+
+	if !result.Errors.HasError() {
+		response := shared.NewHTTPResponse(http.StatusOK, message, result)
+		response.JSON(c.Writer)
+		return
+	}
+
+	// valid rows are still imported, the report tells which rows have to be fixed and sent again
+	response := shared.NewHTTPResponse(http.StatusUnprocessableEntity, message+" With Failed Rows", result, result.Errors)
+	response.JSON(c.Writer)
+}
+
+// readImportRows function for parsing and validating import rows of format from body into rows until body or ctx is done,
+// row that can not be parsed is sent with its error so the rest of body is still imported
+func readImportRows(ctx context.Context, body io.Reader, format string, rows chan<- model.ArticleImportRow) {
+	ctxHandler := "article_handler_read_import_rows"
+
+	defer func() {
+		if r := recover(); r != nil {
+			utils.Log(log.ErrorLevel, fmt.Sprintf("panic: %v", r), ctxHandler, "recover_read_import_rows")
+		}
+		close(rows)
+	}()
+
+	send := func(row model.ArticleImportRow) bool {
+		if row.Err == nil {
+			row.Err = validateImportRow(&row)
+		}
+
+		select {
+		case rows <- row:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	if format == model.TransferCSV {
+		readCSVRows(body, send)
+		return
+	}
+
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64*1024), ndjsonMaxLineSize)
+
+	number := 0
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(strings.TrimSpace(string(line))) == 0 {
+			continue
+		}
+
+		number++
+		row := model.ArticleImportRow{}
+		if err := json.Unmarshal(line, &row); err != nil {
+			row.Err = fmt.Errorf("invalid json: %v", err)
+		}
+		row.Row = number
+
+		if !send(row) {
+			return
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		send(model.ArticleImportRow{Row: number + 1, Err: err})
+	}
+}
+
+// readCSVRows function for parsing csv rows by their header into import rows,
+// unknown column is ignored so exported csv can be imported as it is
+func readCSVRows(body io.Reader, send func(model.ArticleImportRow) bool) {
+	reader := csv.NewReader(body)
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if err != io.EOF {
+			send(model.ArticleImportRow{Row: 1, Err: fmt.Errorf("invalid csv header: %v", err)})
+		}
+		return
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))] = i
+	}
+
+	number := 0
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return
+		}
+		number++
+
+		var parseErr *csv.ParseError
+		if err != nil && !(errors.As(err, &parseErr) && parseErr.Err == csv.ErrFieldCount) {
+			// reader can not find where the broken row ends, the rest of body is not trusted
+			send(model.ArticleImportRow{Row: number, Err: err})
+			return
+		}
+
+		row := csvImportRow(record, columns)
+		row.Row = number
+		if err != nil {
+			row.Err = err
+		}
+
+		if !send(row) {
+			return
+		}
+	}
+}
+
+// csvImportRow function for converting csv record into import row by the position of its columns
+func csvImportRow(record []string, columns map[string]int) model.ArticleImportRow {
+	value := func(name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return record[i]
+		}
+		return ""
+	}
+
+	row := model.ArticleImportRow{
+		Title:       value("title"),
+		Summary:     value("summary"),
+		Description: value("description"),
+		Image:       value("image"),
+		Slug:        value("slug"),
+		Status:      value("status"),
+		Tags:        splitTerms(value("tags")),
+		Categories:  splitTerms(value("categories")),
+	}
+
+	multiError := shared.NewMultiError()
+	if authorID := value("authorId"); authorID != "" {
+		var err error
+		if row.AuthorID, err = strconv.Atoi(authorID); err != nil {
+			multiError.Append("authorId", fmt.Errorf("authorId must be number"))
+		}
+	}
+
+	for name, dest := range map[string]**time.Time{"created": &row.Created, "publishedAt": &row.PublishedAt} {
+		if str := value(name); str != "" {
+			t, err := time.Parse(time.RFC3339, str)
+			if err != nil {
+				multiError.Append(name, fmt.Errorf("%s must be RFC3339 time", name))
+				continue
+			}
+			*dest = &t
+		}
+	}
+
+	if multiError.HasError() {
+		row.Err = multiError
+	}
+
+	return row
+}
+
+// validateImportRow function for validating import row the same way as create article payload
+func validateImportRow(row *model.ArticleImportRow) error {
+	if err := binding.Validator.ValidateStruct(row); err != nil {
+		return err
+	}
+
+	if row.Status != "" && !shared.StringInSlice(row.Status, model.ArticleStatuses) {
+		return fmt.Errorf("status %s is not valid", row.Status)
+	}
+
+	if row.Slug != "" {
+		return shared.ValidateSlug(row.Slug)
+	}
+
+	return nil
+}
+
+// splitTerms function for splitting names of tags or categories joined by model.TermSeparator
+func splitTerms(value string) []model.Tag {
+	var terms []model.Tag
+	for _, name := range strings.Split(value, model.TermSeparator) {
+		if name = strings.TrimSpace(name); name != "" {
+			terms = append(terms, model.Tag{Name: name})
+		}
+	}
+	return terms
+}
+
+// Export method for handling route export articles as csv or ndjson, articles are written and flushed
+// as they are read so the response starts before the last article is loaded, list filters are applied and paging is ignored
+func (h *ArticleHandler) Export(c *gin.Context) {
+	ctxHandler := "article_handler_export"
+	ctx := c.Request.Context()
+	multiError := shared.NewMultiError()
+
+	var params model.ArticleRequestParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		multiError.Append("error", err)
+		utils.Log(log.ErrorLevel, multiError.Error(), ctxHandler, "bind_params")
+		response := shared.NewHTTPResponse(http.StatusBadRequest, "bind params", multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	articleParams, multiError := buildArticleParams(params, false)
+
+	format := c.DefaultQuery("format", model.TransferNDJSON)
+	if !shared.StringInSlice(format, model.TransferFormats) {
+		multiError.Append("format", fmt.Errorf("format must be one of %s", strings.Join(model.TransferFormats, ", ")))
+	}
+
+	if multiError.HasError() {
+		utils.Log(log.ErrorLevel, multiError.Error(), ctxHandler, "validate_params")
+		response := shared.NewHTTPResponse(http.StatusBadRequest, "validate params", multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	if articleParams.IncludeDeleted && !shared.CallerFromContext(ctx).IsAdmin() {
+		multiError.Append("include_deleted", fmt.Errorf("include_deleted is only allowed for admin"))
+		utils.Log(log.ErrorLevel, multiError.Error(), ctxHandler, "validate_caller")
+		response := shared.NewHTTPResponse(http.StatusForbidden, "validate caller", multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	results := h.ArticleUseCase.Export(ctx, articleParams)
+
+	// status is still open until the first result, so early error gets its own response
+	res, ok := <-results
+	if ok && res.Error != nil {
+		utils.Log(log.ErrorLevel, res.Error.Error(), ctxHandler, "err_res_export")
+		response := shared.NewHTTPResponse(errorStatusCode(res.Error), res.Error.Error(), multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	write := newExportWriter(c.Writer, format)
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="articles.%s"`, format))
+	c.Status(http.StatusOK)
+
+	count := 0
+	for ; ok; res, ok = <-results {
+		if res.Error != nil {
+			utils.Log(log.ErrorLevel, res.Error.Error(), ctxHandler, "err_res_export")
+			abortStream(c)
+			return
+		}
+
+		article := res.Result.(model.Article)
+		if err := write(&article); err != nil {
+			utils.Log(log.ErrorLevel, err.Error(), ctxHandler, "write_article")
+			return
+		}
+
+		if count++; count%model.ExportBatchSize == 0 {
+			c.Writer.Flush()
+		}
+	}
+
+	if err := write(nil); err != nil {
+		utils.Log(log.ErrorLevel, err.Error(), ctxHandler, "write_article")
+	}
+	c.Writer.Flush()
+}
+
+// newExportWriter function for creating writer of exported article in format and setting its content type,
+// csv header is written at once and nil article flushes what is still buffered
+func newExportWriter(w gin.ResponseWriter, format string) func(article *model.Article) error {
+	if format == model.TransferNDJSON {
+		w.Header().Set("Content-Type", ndjsonContentType)
+		encoder := json.NewEncoder(w)
+		encoder.SetEscapeHTML(false)
+
+		return func(article *model.Article) error {
+			if article == nil {
+				return nil
+			}
+			return encoder.Encode(article)
+		}
+	}
+
+	w.Header().Set("Content-Type", csvContentType+"; charset=utf-8")
+	writer := csv.NewWriter(w)
+	writer.Write(model.TransferColumns)
+
+	return func(article *model.Article) error {
+		if article == nil {
+			writer.Flush()
+			return writer.Error()
+		}
+
+		authorID := ""
+		if article.AuthorID > 0 {
+			authorID = strconv.Itoa(article.AuthorID)
+		}
+
+		return writer.Write([]string{
+			strconv.Itoa(article.ID), article.Slug, article.Title, article.Summary, article.Description, article.Image,
+			article.Status, authorID, article.Created.Format(time.RFC3339Nano), article.Modified, article.PublishedAt,
+			joinTerms(article.Tags), joinTerms(article.Categories),
+		})
+	}
+}
+
+// joinTerms function for joining names of tags or categories by model.TermSeparator
+func joinTerms(terms []model.Tag) string {
+	names := make([]string, 0, len(terms))
+	for _, term := range terms {
+		names = append(names, term.Name)
+	}
+	return strings.Join(names, model.TermSeparator)
+}
+
+// abortStream function for closing connection of streamed response without ending its body,
+// client sees the response as broken instead of a complete but truncated export
+func abortStream(c *gin.Context) {
+	c.Writer.Flush()
+	if conn, _, err := c.Writer.Hijack(); err == nil {
+		conn.Close()
+	}
+}
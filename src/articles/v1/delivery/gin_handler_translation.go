@@ -0,0 +1,135 @@
+package delivery
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/willy182/boilerplate-go-cleanarch/src/articles/v1/model"
+	"github.com/willy182/boilerplate-go-cleanarch/src/shared"
+	"github.com/willy182/boilerplate-go-cleanarch/utils"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+)
+
+// SaveTranslation method for handling route create or replace translation of article by ID
+func (h *ArticleHandler) SaveTranslation(c *gin.Context) {
+	ctxHandler := "article_handler_save_translation"
+	ctx := c.Request.Context()
+	multiError := shared.NewMultiError()
+
+	id, ok := h.validateID(c, ctxHandler)
+	if !ok {
+		return
+	}
+
+	var param model.ArticleTranslationRequest
+	if err := c.ShouldBindJSON(&param); err != nil {
+		multiError.Append("error", err)
+		utils.Log(log.ErrorLevel, multiError.Error(), ctxHandler, "bind_payload")
+		response := shared.NewHTTPResponse(http.StatusBadRequest, "bind payload", multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	// content of default locale is edited on the article itself
+	if !shared.StringInSlice(param.Locale, model.Locales) || param.Locale == model.DefaultLocale {
+		multiError.Append("locale", fmt.Errorf("locale must be one of %s except %s", strings.Join(model.Locales, ", "), model.DefaultLocale))
+		utils.Log(log.ErrorLevel, multiError.Error(), ctxHandler, "validate_locale")
+		response := shared.NewHTTPResponse(http.StatusBadRequest, "validate locale", multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	res := <-h.ArticleUseCase.SaveTranslation(ctx, id, param)
+	if res.Error != nil {
+		utils.Log(log.ErrorLevel, res.Error.Error(), ctxHandler, "err_res_save_translation")
+		response := shared.NewHTTPResponse(errorStatusCode(res.Error), res.Error.Error(), multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	result := res.Result.(model.ArticleTranslation)
+	response := shared.NewHTTPResponse(http.StatusOK, "Article Translation Saved", result)
+	response.JSON(c.Writer)
+}
+
+// GetTranslations method for handling route translation list of article by ID
+func (h *ArticleHandler) GetTranslations(c *gin.Context) {
+	ctxHandler := "article_handler_get_translations"
+	ctx := c.Request.Context()
+	multiError := shared.NewMultiError()
+
+	id, ok := h.validateID(c, ctxHandler)
+	if !ok {
+		return
+	}
+
+	res := <-h.ArticleUseCase.GetTranslations(ctx, id)
+	if res.Error != nil {
+		utils.Log(log.ErrorLevel, res.Error.Error(), ctxHandler, "err_res_get_translations")
+		response := shared.NewHTTPResponse(errorStatusCode(res.Error), res.Error.Error(), multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	result := res.Result.([]model.ArticleTranslation)
+	response := shared.NewHTTPResponse(http.StatusOK, "Article Translation List", result)
+	response.JSON(c.Writer)
+}
+
+// negotiateLocales function for getting fallback chain of requested locales, lang query param comes first
+// then Accept-Language tags each followed by its base language and model.DefaultLocale is always the last one,
+// response will be written when lang is not supported
+func (h *ArticleHandler) negotiateLocales(c *gin.Context, ctxHandler string) ([]string, bool) {
+	// representation of article depends on the header even when lang is sent
+	c.Header("Vary", "Accept-Language")
+
+	var locales []string
+	add := func(locale string) {
+		if shared.StringInSlice(locale, model.Locales) && !shared.StringInSlice(locale, locales) {
+			locales = append(locales, locale)
+		}
+	}
+
+	if lang, ok := c.GetQuery("lang"); ok {
+		if !shared.StringInSlice(lang, model.Locales) {
+			multiError := shared.NewMultiError()
+			multiError.Append("lang", fmt.Errorf("lang must be one of %s", strings.Join(model.Locales, ", ")))
+			utils.Log(log.ErrorLevel, multiError.Error(), ctxHandler, "validate_lang")
+			response := shared.NewHTTPResponse(http.StatusBadRequest, "validate params", multiError)
+			response.JSON(c.Writer)
+			return nil, false
+		}
+		add(lang)
+	}
+
+	for _, tag := range shared.ParseAcceptLanguage(c.GetHeader("Accept-Language")) {
+		add(tag)
+		if i := strings.IndexByte(tag, '-'); i > 0 {
+			add(tag[:i])
+		}
+	}
+	add(model.DefaultLocale)
+
+	return locales, true
+}
+
+// translateArticles function for replacing content of articles with their translation of negotiated locales,
+// response will be written when the translations can not be loaded
+func (h *ArticleHandler) translateArticles(c *gin.Context, ctxHandler string, articles []model.Article, locales []string) ([]model.Article, bool) {
+	if len(articles) == 0 {
+		return articles, true
+	}
+
+	res := <-h.ArticleUseCase.Translate(c.Request.Context(), articles, locales)
+	if res.Error != nil {
+		utils.Log(log.ErrorLevel, res.Error.Error(), ctxHandler, "err_res_translate")
+		response := shared.NewHTTPResponse(errorStatusCode(res.Error), res.Error.Error(), shared.NewMultiError())
+		response.JSON(c.Writer)
+		return nil, false
+	}
+
+	return res.Result.([]model.Article), true
+}
@@ -1,25 +1,205 @@
 package model
 
-import "time"
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+
+	authorModel "github.com/willy182/boilerplate-go-cleanarch/src/authors/v1/model"
+)
+
+var (
+	// ErrInvalidCursor error when cursor of article list can not be decoded
+	ErrInvalidCursor = errors.New("invalid cursor")
+	// ErrSlugUsed error when requested slug is already used by another article
+	ErrSlugUsed = errors.New("slug is already used by another article")
+	// ErrAuthorNotFound error when requested author of article does not exist
+	ErrAuthorNotFound = errors.New("author does not exist")
+)
 
 // GormArticle data of struct
 type GormArticle struct {
-	ID          int        `gorm:"AUTO_INCREMENT;PRIMARY_KEY"`
-	Title       string     `gorm:"type:varchar(100);NOT NULL"`
-	Summary     string     `gorm:"type:varchar(250);NOT NULL"`
-	Description string     `gorm:"type:text"`
-	Image       string     `gorm:"type:varchar(150)"`
-	Created     *time.Time `gorm:"type:timestamp(6) with time zone;NOT NULL"`
-	Modified    *time.Time `gorm:"type:timestamp(6) with time zone"`
+	ID              int        `gorm:"AUTO_INCREMENT;PRIMARY_KEY"`
+	Title           string     `gorm:"type:varchar(100);NOT NULL"`
+	Summary         string     `gorm:"type:varchar(250);NOT NULL"`
+	Description     string     `gorm:"type:text"`
+	DescriptionHTML string     `gorm:"type:text"`
+	Image           string     `gorm:"type:varchar(500)"`
+	Created         *time.Time `gorm:"type:timestamp(6) with time zone;NOT NULL"`
+	Modified        *time.Time `gorm:"type:timestamp(6) with time zone"`
+	Version         int        `gorm:"NOT NULL;DEFAULT:1"`
+	Deleted         *time.Time `gorm:"type:timestamp(6) with time zone"`
+	Status          string     `gorm:"type:varchar(20);NOT NULL;DEFAULT:'draft'"`
+	PublishedAt     *time.Time `gorm:"type:timestamp(6) with time zone"`
+	PublishAt       *time.Time `gorm:"type:timestamp(6) with time zone"`
+	UnpublishAt     *time.Time `gorm:"type:timestamp(6) with time zone"`
+	Slug            string     `gorm:"type:varchar(150);NOT NULL;unique_index"`
+	AuthorID        *int       `gorm:"index"`
+	ImageVariants   string     `gorm:"type:jsonb"`
+	CommentCount    int        `gorm:"NOT NULL;DEFAULT:0"`
 }
 
 // Article data of struct
 type Article struct {
-	ID          int       `json:"id"`
-	Title       string    `json:"title"`
-	Summary     string    `json:"summary"`
-	Description string    `json:"description,omitempty"`
-	Image       string    `json:"image,omitempty"`
-	Created     time.Time `json:"created"`
-	Modified    string    `json:"modified,omitempty"`
+	ID              int                 `json:"id"`
+	Slug            string              `json:"slug"`
+	Title           string              `json:"title"`
+	Summary         string              `json:"summary"`
+	Description     string              `json:"description,omitempty"`
+	DescriptionHTML string              `json:"-"`
+	Image           string              `json:"image,omitempty"`
+	ImageVariants   map[string]string   `json:"imageVariants,omitempty"`
+	Created         time.Time           `json:"created"`
+	Modified        string              `json:"modified,omitempty"`
+	Version         int                 `json:"version"`
+	Deleted         string              `json:"deleted,omitempty"`
+	Status          string              `json:"status"`
+	CommentCount    int                 `json:"commentCount"`
+	Locale          string              `json:"locale,omitempty"`
+	PublishedAt     string              `json:"publishedAt,omitempty"`
+	PublishAt       string              `json:"publishAt,omitempty"`
+	UnpublishAt     string              `json:"unpublishAt,omitempty"`
+	Tags            []Tag               `json:"tags,omitempty"`
+	Categories      []Category          `json:"categories,omitempty"`
+	AuthorID        int                 `json:"authorId,omitempty"`
+	Author          *authorModel.Author `json:"author,omitempty"`
+}
+
+// ArticleRequest data of struct for create and replace article payload
+type ArticleRequest struct {
+	Title       string `json:"title" binding:"required,max=100"`
+	Summary     string `json:"summary" binding:"required,max=250"`
+	Description string `json:"description" binding:"max=50000"`
+	Image       string `json:"image" binding:"max=500"`
+	Slug        string `json:"slug" binding:"max=150"`
+	AuthorID    int    `json:"authorId" binding:"omitempty,min=1"`
+	Version     int    `json:"version"`
+}
+
+// ArticlePatchRequest data of struct for partial update article payload,
+// nil field means the field is not changed
+type ArticlePatchRequest struct {
+	Title       *string `json:"title" binding:"omitempty,min=1,max=100"`
+	Summary     *string `json:"summary" binding:"omitempty,min=1,max=250"`
+	Description *string `json:"description" binding:"omitempty,max=50000"`
+	Image       *string `json:"image" binding:"omitempty,max=500"`
+	Slug        *string `json:"slug" binding:"omitempty,max=150"`
+	AuthorID    *int    `json:"authorId" binding:"omitempty,min=1"`
+	Version     int     `json:"version"`
+}
+
+// LastModified function for getting the last time article is changed
+func (a *Article) LastModified() time.Time {
+	if t, err := time.Parse(time.RFC3339, a.Modified); err == nil {
+		return t
+	}
+	return a.Created
+}
+
+// ArticleRequestParams data of struct for article list query params
+type ArticleRequestParams struct {
+	PageNumber     string `form:"page"`
+	PageSize       string `form:"limit"`
+	Cursor         string `form:"cursor"`
+	Query          string `form:"q"`
+	CreatedFrom    string `form:"created_from"`
+	CreatedTo      string `form:"created_to"`
+	ModifiedFrom   string `form:"modified_from"`
+	ModifiedTo     string `form:"modified_to"`
+	Sort           string `form:"sort"`
+	IncludeDeleted string `form:"include_deleted"`
+	Status         string `form:"status"`
+	Tag            string `form:"tag"`
+	Category       string `form:"category"`
+	Include        string `form:"include"`
+	Fields         string `form:"fields"`
+}
+
+// ArticleParams data of struct for filtering article list
+type ArticleParams struct {
+	Page           int
+	Limit          int
+	Query          string
+	CreatedFrom    *time.Time
+	CreatedTo      *time.Time
+	ModifiedFrom   *time.Time
+	ModifiedTo     *time.Time
+	Sort           []SortField
+	Cursor         *ArticleCursor
+	IncludeDeleted bool
+	Status         []string
+	Tag            string
+	Category       string
+	AuthorID       int
+	Include        []string
+	Fields         []string
+	Language       string
+}
+
+// SortField data of struct for sorting article list,
+// Field is one of ArticleSortFields
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+// ArticleList data of struct for article list with its total records
+type ArticleList struct {
+	Data  []Article
+	Total int
+}
+
+// ArticleSortFields list of field that can be used for sorting article list
+var ArticleSortFields = []string{"id", "title", "created", "modified", "publishedAt"}
+
+// ArticleCursor data of struct for keyset pagination of article list,
+// zero value means the first page
+type ArticleCursor struct {
+	Created time.Time `json:"c"`
+	ID      int       `json:"i"`
+	Prev    bool      `json:"p,omitempty"`
+}
+
+// ArticleCursorList data of struct for article list with its next and previous cursor
+type ArticleCursorList struct {
+	Data []Article
+	Next *ArticleCursor
+	Prev *ArticleCursor
+}
+
+// IsFirst function for checking whether the cursor points to the first page
+func (c *ArticleCursor) IsFirst() bool {
+	return c.ID == 0 && c.Created.IsZero()
+}
+
+// Encode function for encoding cursor into opaque string, nil cursor is encoded as empty string
+func (c *ArticleCursor) Encode() string {
+	if c == nil {
+		return ""
+	}
+
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// DecodeArticleCursor function for decoding opaque string into cursor,
+// empty string is decoded as the first page
+func DecodeArticleCursor(str string) (*ArticleCursor, error) {
+	cursor := new(ArticleCursor)
+	if str == "" {
+		return cursor, nil
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(str)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	// zero id is only valid next to creation time, see the boundary of empty page in GetAllByCursor
+	if err := json.Unmarshal(b, cursor); err != nil || cursor.ID < 0 || cursor.IsFirst() {
+		return nil, ErrInvalidCursor
+	}
+
+	return cursor, nil
 }
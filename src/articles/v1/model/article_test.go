@@ -0,0 +1,74 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestArticleCursorEncodeDecode(t *testing.T) {
+	created := time.Date(2020, 5, 1, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		cursor *ArticleCursor
+	}{
+		{name: "next", cursor: &ArticleCursor{Created: created, ID: 42}},
+		{name: "prev", cursor: &ArticleCursor{Created: created, ID: 42, Prev: true}},
+		{name: "boundary of empty page", cursor: &ArticleCursor{Created: created, ID: 0, Prev: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeArticleCursor(tt.cursor.Encode())
+			if err != nil {
+				t.Fatalf("DecodeArticleCursor() error = %v", err)
+			}
+
+			if !got.Created.Equal(tt.cursor.Created) || got.ID != tt.cursor.ID || got.Prev != tt.cursor.Prev {
+				t.Errorf("DecodeArticleCursor() = %+v, want %+v", got, tt.cursor)
+			}
+		})
+	}
+}
+
+func TestArticleCursorEncodeNil(t *testing.T) {
+	var cursor *ArticleCursor
+	if got := cursor.Encode(); got != "" {
+		t.Errorf("Encode() = %q, want empty", got)
+	}
+}
+
+func TestDecodeArticleCursor(t *testing.T) {
+	tests := []struct {
+		name    string
+		str     string
+		first   bool
+		wantErr bool
+	}{
+		{name: "empty is first page", str: "", first: true},
+		{name: "not base64", str: "!!!", wantErr: true},
+		{name: "not json", str: "bm90LWpzb24", wantErr: true},
+		{name: "negative id", str: (&ArticleCursor{Created: time.Now(), ID: -1}).Encode(), wantErr: true},
+		{name: "zero value", str: (&ArticleCursor{}).Encode(), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeArticleCursor(tt.str)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DecodeArticleCursor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err != nil {
+				if err != ErrInvalidCursor {
+					t.Errorf("DecodeArticleCursor() error = %v, want %v", err, ErrInvalidCursor)
+				}
+				return
+			}
+
+			if got.IsFirst() != tt.first {
+				t.Errorf("IsFirst() = %v, want %v", got.IsFirst(), tt.first)
+			}
+		})
+	}
+}
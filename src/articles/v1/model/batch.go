@@ -0,0 +1,71 @@
+package model
+
+import "errors"
+
+const (
+	// BatchCreate action of batch operation for creating article
+	BatchCreate = "create"
+	// BatchUpdate action of batch operation for replacing every field of article
+	BatchUpdate = "update"
+	// BatchDelete action of batch operation for soft deleting article
+	BatchDelete = "delete"
+	// BatchStatus action of batch operation for changing status of article
+	BatchStatus = "status"
+	// BatchModeAtomic mode of batch that applies every operation or none of them
+	BatchModeAtomic = "atomic"
+	// BatchModeItem mode of batch that applies every operation that succeeds and skips the failed ones
+	BatchModeItem = "item"
+)
+
+// ErrBatchRolledBack error of operation that is not applied because another operation of atomic batch failed
+var ErrBatchRolledBack = errors.New("rolled back because another operation of the batch failed")
+
+// BatchActions list of action that can be used by batch operation
+var BatchActions = []string{BatchCreate, BatchUpdate, BatchDelete, BatchStatus}
+
+// BatchModes list of mode that can be used by batch
+var BatchModes = []string{BatchModeAtomic, BatchModeItem}
+
+// ArticleBatchRequest data of struct for article batch payload of at most 100 operations, empty mode means BatchModeAtomic
+type ArticleBatchRequest struct {
+	Mode       string                  `json:"mode"`
+	Operations []ArticleBatchOperation `json:"operations" binding:"required,min=1,max=100"`
+}
+
+// ArticleBatchOperation data of struct for single operation of article batch, Article is the payload of create and update,
+// Version is the version of article before the operation, Tags and Categories are attached on create and update,
+// Err is filled when the operation is not valid before it reaches use case
+type ArticleBatchOperation struct {
+	Action     string          `json:"action"`
+	ID         int             `json:"id"`
+	Article    *ArticleRequest `json:"article"`
+	Status     string          `json:"status"`
+	Version    int             `json:"version"`
+	Tags       []string        `json:"tags"`
+	Categories []string        `json:"categories"`
+	Err        error           `json:"-"`
+}
+
+// ArticleBatchItem data of struct for validated batch operation that is applied by repository,
+// Index is the position of the operation in the batch
+type ArticleBatchItem struct {
+	Index      int
+	Action     string
+	ID         int
+	Article    *GormArticle
+	Status     string
+	Version    int
+	Tags       []Tag
+	Categories []Category
+}
+
+// ArticleBatchResult data of struct for result of single batch operation by its position,
+// Article is the state after create, update or status change
+type ArticleBatchResult struct {
+	Index   int      `json:"index"`
+	Action  string   `json:"action"`
+	ID      int      `json:"id,omitempty"`
+	Applied bool     `json:"applied"`
+	Article *Article `json:"article,omitempty"`
+	Err     error    `json:"-"`
+}
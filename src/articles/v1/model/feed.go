@@ -0,0 +1,26 @@
+package model
+
+import "time"
+
+const (
+	// FeedLimit maximum number of latest articles in a feed
+	FeedLimit = 50
+	// FeedRSS RSS 2.0 feed format
+	FeedRSS = "rss"
+	// FeedAtom Atom 1.0 feed format
+	FeedAtom = "atom"
+)
+
+// ArticleFeedParams data of struct for narrowing article feed into a tag or an author
+type ArticleFeedParams struct {
+	Tag      string
+	AuthorID int
+}
+
+// ArticleFeed data of struct for latest published articles of a feed,
+// LastModified is the last change of any article in the feed scope, including those that left the feed
+type ArticleFeed struct {
+	Title        string
+	Articles     []Article
+	LastModified time.Time
+}
@@ -0,0 +1,81 @@
+package model
+
+const (
+	// IncludeAuthor include param for expanding author of article
+	IncludeAuthor = "author"
+	// IncludeTags include param for expanding tags of article
+	IncludeTags = "tags"
+	// IncludeCategories include param for expanding categories of article
+	IncludeCategories = "categories"
+)
+
+// ArticleIncludes list of relation that can be expanded on article response
+var ArticleIncludes = []string{IncludeAuthor, IncludeTags, IncludeCategories}
+
+// ArticleFields list of field that can be requested as sparse fieldset of article response
+var ArticleFields = []string{"id", "slug", "title", "summary", "description", "image", "imageVariants", "created", "modified",
+	"version", "deleted", "status", "commentCount", "locale", "publishedAt", "publishAt", "unpublishAt", "authorId"}
+
+// ArticleRequiredFields list of field that is always loaded for identifying, paginating, caching,
+// checking visibility and expanding article even when it is not requested
+var ArticleRequiredFields = []string{"id", "created", "modified", "version", "status", "authorId"}
+
+// Project function for converting article into map of requested fields and included relations,
+// the keys are the same as json name of article
+func (a *Article) Project(fields, include []string) map[string]interface{} {
+	result := make(map[string]interface{}, len(fields)+len(include))
+
+	for _, field := range fields {
+		switch field {
+		case "id":
+			result[field] = a.ID
+		case "slug":
+			result[field] = a.Slug
+		case "title":
+			result[field] = a.Title
+		case "summary":
+			result[field] = a.Summary
+		case "description":
+			result[field] = a.Description
+		case "image":
+			result[field] = a.Image
+		case "imageVariants":
+			result[field] = a.ImageVariants
+		case "created":
+			result[field] = a.Created
+		case "modified":
+			result[field] = a.Modified
+		case "version":
+			result[field] = a.Version
+		case "deleted":
+			result[field] = a.Deleted
+		case "status":
+			result[field] = a.Status
+		case "commentCount":
+			result[field] = a.CommentCount
+		case "locale":
+			result[field] = a.Locale
+		case "publishedAt":
+			result[field] = a.PublishedAt
+		case "publishAt":
+			result[field] = a.PublishAt
+		case "unpublishAt":
+			result[field] = a.UnpublishAt
+		case "authorId":
+			result[field] = a.AuthorID
+		}
+	}
+
+	for _, relation := range include {
+		switch relation {
+		case IncludeAuthor:
+			result[relation] = a.Author
+		case IncludeTags:
+			result[relation] = a.Tags
+		case IncludeCategories:
+			result[relation] = a.Categories
+		}
+	}
+
+	return result
+}
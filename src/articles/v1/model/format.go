@@ -0,0 +1,13 @@
+package model
+
+const (
+	// FormatMarkdown description format of markdown source as authored
+	FormatMarkdown = "markdown"
+	// FormatHTML description format of sanitized html rendered from markdown source
+	FormatHTML = "html"
+	// FormatText description format of plain text without any markup
+	FormatText = "text"
+)
+
+// DescriptionFormats list of format that description of article can be returned in
+var DescriptionFormats = []string{FormatMarkdown, FormatHTML, FormatText}
@@ -0,0 +1,49 @@
+package model
+
+import "errors"
+
+const (
+	// ImageMaxSize maximum size of uploaded image in bytes
+	ImageMaxSize = 5 << 20
+	// ImageMaxPixels maximum width times height of uploaded image, about 4096x4096,
+	// it keeps memory of one decoded image at around 64MB
+	ImageMaxPixels = 16 << 20
+	// ImageMaxDecodes maximum number of uploaded images decoded and resized at the same time
+	ImageMaxDecodes = 2
+	// ImageFormField multipart form field of uploaded image
+	ImageFormField = "image"
+)
+
+var (
+	// ErrImageType error when uploaded file is not supported image
+	ErrImageType = errors.New("image must be jpeg, png or gif")
+	// ErrImageTooLarge error when uploaded image is bigger than ImageMaxSize
+	ErrImageTooLarge = errors.New("image must not be larger than 5MB")
+	// ErrImageDimension error when uploaded image has more pixels than ImageMaxPixels
+	ErrImageDimension = errors.New("image dimension is too large")
+)
+
+// ImageVariant data of struct for resized variant of article image
+type ImageVariant struct {
+	Name  string
+	Width int
+}
+
+// ImageVariants list of resized variant generated from uploaded image, smallest first
+var ImageVariants = []ImageVariant{
+	{Name: "thumbnail", Width: 320},
+	{Name: "medium", Width: 800},
+	{Name: "large", Width: 1600},
+}
+
+// ImageTypes mapping of supported image content type into its file extension
+var ImageTypes = map[string]string{
+	"image/jpeg": "jpg",
+	"image/png":  "png",
+	"image/gif":  "gif",
+}
+
+// ArticleImageUpload data of struct for uploaded article image
+type ArticleImageUpload struct {
+	Data []byte
+}
@@ -0,0 +1,42 @@
+package model
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ArticleDefaultPagePath path of public article page on SiteURL when ArticleLink.PagePath is empty
+const ArticleDefaultPagePath = "/articles/{slug}"
+
+// ArticleLink data of struct for building absolute url of article in sitemap and feed,
+// public article page is used when SiteURL is set, otherwise the api resource on the requesting host is used
+// because this service does not serve any article page itself
+type ArticleLink struct {
+	// SiteURL absolute url of public site, e.g. https://example.com
+	SiteURL string
+	// PagePath path of article page on SiteURL, {slug} and {id} are replaced by the article
+	PagePath string
+}
+
+// URL function for getting absolute url of article, host is the url this service is requested on,
+// id is used in place of slug when article has no slug
+func (l ArticleLink) URL(host string, id int, slug string) string {
+	if l.SiteURL == "" {
+		if slug == "" {
+			return host + "/v1/article/" + strconv.Itoa(id)
+		}
+		return host + "/v1/article/slug/" + slug
+	}
+
+	if slug == "" {
+		slug = strconv.Itoa(id)
+	}
+
+	path := l.PagePath
+	if path == "" {
+		path = ArticleDefaultPagePath
+	}
+	path = strings.NewReplacer("{slug}", slug, "{id}", strconv.Itoa(id)).Replace(path)
+
+	return strings.TrimRight(l.SiteURL, "/") + path
+}
@@ -0,0 +1,21 @@
+package model
+
+import "time"
+
+const (
+	// RelatedDefaultLimit number of related articles when limit is not requested
+	RelatedDefaultLimit = 5
+	// RelatedMaxLimit maximum number of related articles, it is also the number of articles that is cached
+	RelatedMaxLimit = 20
+	// RelatedCacheTTL lifetime of cached related articles, the cache lives in memory of each replica
+	// and a write only invalidates the replica that handled it, so other replicas may serve stale entries this long
+	RelatedCacheTTL = 2 * time.Minute
+	// RelatedTagWeight score of each tag shared with the article
+	RelatedTagWeight = 1.0
+	// RelatedTextWeight score of full trigram similarity of title and summary with the article
+	RelatedTextWeight = 2.0
+	// RelatedRecencyWeight score of article published just now, it is halved after RelatedRecencyDays
+	RelatedRecencyWeight = 0.5
+	// RelatedRecencyDays age in days of article that gets half of RelatedRecencyWeight
+	RelatedRecencyDays = 30
+)
@@ -0,0 +1,54 @@
+package model
+
+import "time"
+
+// GormArticleRevision data of struct
+type GormArticleRevision struct {
+	ID          int        `gorm:"AUTO_INCREMENT;PRIMARY_KEY"`
+	ArticleID   int        `gorm:"NOT NULL;unique_index:idx_article_revisions_article_id_revision"`
+	Revision    int        `gorm:"NOT NULL;unique_index:idx_article_revisions_article_id_revision"`
+	Author      string     `gorm:"type:varchar(100);NOT NULL"`
+	Title       string     `gorm:"type:varchar(100);NOT NULL"`
+	Summary     string     `gorm:"type:varchar(250);NOT NULL"`
+	Description string     `gorm:"type:text"`
+	Image       string     `gorm:"type:varchar(500)"`
+	Status      string     `gorm:"type:varchar(20)"`
+	Slug        string     `gorm:"type:varchar(150)"`
+	AuthorID    *int       `gorm:"type:integer"`
+	Created     *time.Time `gorm:"type:timestamp(6) with time zone;NOT NULL"`
+}
+
+// ArticleRevision data of struct
+type ArticleRevision struct {
+	ArticleID   int       `json:"articleId"`
+	Revision    int       `json:"revision"`
+	Author      string    `json:"author"`
+	Title       string    `json:"title"`
+	Summary     string    `json:"summary"`
+	Description string    `json:"description,omitempty"`
+	Image       string    `json:"image,omitempty"`
+	Status      string    `json:"status,omitempty"`
+	Slug        string    `json:"slug,omitempty"`
+	AuthorID    int       `json:"authorId,omitempty"`
+	Created     time.Time `json:"created"`
+}
+
+// FieldChange data of struct for changed field between two revisions
+type FieldChange struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+// ArticleRevisionDiff data of struct for field level difference between two revisions
+type ArticleRevisionDiff struct {
+	ArticleID int           `json:"articleId"`
+	From      int           `json:"from"`
+	To        int           `json:"to"`
+	Changes   []FieldChange `json:"changes"`
+}
+
+// ArticleVersionRequest data of struct for payload of action that needs article version only
+type ArticleVersionRequest struct {
+	Version int `json:"version"`
+}
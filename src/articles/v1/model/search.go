@@ -0,0 +1,33 @@
+package model
+
+const (
+	// SearchLanguageSimple text search configuration without stemming, suitable for any language
+	SearchLanguageSimple = "simple"
+	// SearchLanguageEnglish text search configuration with english stemming
+	SearchLanguageEnglish = "english"
+)
+
+// SearchLanguages list of supported text search configuration
+var SearchLanguages = []string{SearchLanguageSimple, SearchLanguageEnglish}
+
+// ArticleSearchRequestParams data of struct for article search query params
+type ArticleSearchRequestParams struct {
+	PageNumber string `form:"page"`
+	PageSize   string `form:"limit"`
+	Query      string `form:"q"`
+	Language   string `form:"lang"`
+}
+
+// ArticleSearchResult data of struct for article search result with its rank and highlighted snippet,
+// headline is HTML escaped text where only matched words are wrapped in <mark>
+type ArticleSearchResult struct {
+	Article
+	Rank     float64 `json:"rank"`
+	Headline string  `json:"headline"`
+}
+
+// ArticleSearchList data of struct for article search result with its total records
+type ArticleSearchList struct {
+	Data  []ArticleSearchResult
+	Total int
+}
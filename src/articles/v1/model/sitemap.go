@@ -0,0 +1,58 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// SitemapMaxURLs maximum number of url in a single sitemap as defined by sitemaps.org protocol
+	SitemapMaxURLs = 50000
+	// SitemapRoot position of root sitemap, which is the sitemap index when articles do not fit into a single sitemap
+	SitemapRoot = -1
+)
+
+// SitemapEntry data of struct for published article in sitemap
+type SitemapEntry struct {
+	ID       int
+	Slug     string
+	Modified time.Time
+}
+
+// SitemapPage data of struct for a sitemap of SitemapMaxURLs articles listed by sitemap index,
+// the page lists articles whose id is greater than After so it is read by keyset instead of offset
+type SitemapPage struct {
+	After    int
+	Total    int
+	Modified time.Time
+}
+
+// Sitemap data of struct for sitemap, Index is filled when the articles do not fit into a single sitemap,
+// otherwise Entries is filled
+type Sitemap struct {
+	Index   []SitemapPage
+	Entries []SitemapEntry
+}
+
+// Path function for getting path of sitemap page listed by sitemap index
+func (p SitemapPage) Path() string {
+	return fmt.Sprintf("/sitemaps/articles/after-%d.xml", p.After)
+}
+
+// LastModified function for getting the latest modified time of sitemap
+func (s Sitemap) LastModified() time.Time {
+	var lastModified time.Time
+	for _, page := range s.Index {
+		if page.Modified.After(lastModified) {
+			lastModified = page.Modified
+		}
+	}
+
+	for _, entry := range s.Entries {
+		if entry.Modified.After(lastModified) {
+			lastModified = entry.Modified
+		}
+	}
+
+	return lastModified
+}
@@ -0,0 +1,47 @@
+package model
+
+import "testing"
+
+func TestSitemapPagePath(t *testing.T) {
+	tests := []struct {
+		name     string
+		page     SitemapPage
+		expected string
+	}{
+		{"first page", SitemapPage{After: 0}, "/sitemaps/articles/after-0.xml"},
+		{"next page", SitemapPage{After: 50123}, "/sitemaps/articles/after-50123.xml"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if path := tt.page.Path(); path != tt.expected {
+				t.Errorf("path = %q, expected %q", path, tt.expected)
+			}
+		})
+	}
+}
+
+func TestArticleLinkURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		link     ArticleLink
+		id       int
+		slug     string
+		expected string
+	}{
+		{"api with slug", ArticleLink{}, 7, "hello-world", "http://api.local/v1/article/slug/hello-world"},
+		{"api without slug", ArticleLink{}, 7, "", "http://api.local/v1/article/7"},
+		{"site default path", ArticleLink{SiteURL: "https://example.com/"}, 7, "hello-world", "https://example.com/articles/hello-world"},
+		{"site without slug", ArticleLink{SiteURL: "https://example.com"}, 7, "", "https://example.com/articles/7"},
+		{"site custom path", ArticleLink{SiteURL: "https://example.com", PagePath: "/blog/{id}/{slug}"}, 7, "hello-world",
+			"https://example.com/blog/7/hello-world"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if url := tt.link.URL("http://api.local", tt.id, tt.slug); url != tt.expected {
+				t.Errorf("url = %q, expected %q", url, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,10 @@
+package model
+
+import "time"
+
+// GormArticleSlugHistory data of struct for old slug of article that is redirected into its current slug
+type GormArticleSlugHistory struct {
+	Slug      string     `gorm:"type:varchar(150);PRIMARY_KEY"`
+	ArticleID int        `gorm:"NOT NULL;index"`
+	Created   *time.Time `gorm:"type:timestamp(6) with time zone;NOT NULL"`
+}
@@ -0,0 +1,46 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	// StatusDraft status of article that is still being written
+	StatusDraft = "draft"
+	// StatusInReview status of article that is waiting for review
+	StatusInReview = "in_review"
+	// StatusPublished status of article that is visible to public
+	StatusPublished = "published"
+	// StatusArchived status of article that is no longer maintained
+	StatusArchived = "archived"
+
+	// ScheduleAuthor author of revision written when scheduled publishing changes status of article
+	ScheduleAuthor = "scheduler"
+)
+
+// ErrInvalidTransition error when article status can not be changed into requested status
+var ErrInvalidTransition = errors.New("invalid status transition")
+
+// ArticleStatuses list of valid article status
+var ArticleStatuses = []string{StatusDraft, StatusInReview, StatusPublished, StatusArchived}
+
+// ArticleStatusRequest data of struct for change article status payload
+type ArticleStatusRequest struct {
+	Status  string `json:"status" binding:"required"`
+	Version int    `json:"version"`
+}
+
+// ArticleScheduleRequest data of struct for scheduling article publishing payload,
+// nil time means the schedule is cleared
+type ArticleScheduleRequest struct {
+	PublishAt   *time.Time `json:"publishAt"`
+	UnpublishAt *time.Time `json:"unpublishAt"`
+	Version     int        `json:"version"`
+}
+
+// ScheduleResult data of struct for result of applying due publishing schedule
+type ScheduleResult struct {
+	Published   int
+	Unpublished int
+}
@@ -0,0 +1,58 @@
+package model
+
+import "time"
+
+// GormTag data of struct for tag of article
+type GormTag struct {
+	ID      int        `gorm:"AUTO_INCREMENT;PRIMARY_KEY"`
+	Name    string     `gorm:"type:varchar(50);NOT NULL"`
+	Slug    string     `gorm:"type:varchar(60);NOT NULL;unique_index"`
+	Created *time.Time `gorm:"type:timestamp(6) with time zone;NOT NULL"`
+}
+
+// GormCategory data of struct for category of article
+type GormCategory struct {
+	ID      int        `gorm:"AUTO_INCREMENT;PRIMARY_KEY"`
+	Name    string     `gorm:"type:varchar(50);NOT NULL"`
+	Slug    string     `gorm:"type:varchar(60);NOT NULL;unique_index"`
+	Created *time.Time `gorm:"type:timestamp(6) with time zone;NOT NULL"`
+}
+
+// GormArticleTag data of struct for relation of article and its tag
+type GormArticleTag struct {
+	ArticleID int        `gorm:"PRIMARY_KEY;auto_increment:false"`
+	TagID     int        `gorm:"PRIMARY_KEY;auto_increment:false;index"`
+	Created   *time.Time `gorm:"type:timestamp(6) with time zone;NOT NULL"`
+}
+
+// GormArticleCategory data of struct for relation of article and its category
+type GormArticleCategory struct {
+	ArticleID  int        `gorm:"PRIMARY_KEY;auto_increment:false"`
+	CategoryID int        `gorm:"PRIMARY_KEY;auto_increment:false;index"`
+	Created    *time.Time `gorm:"type:timestamp(6) with time zone;NOT NULL"`
+}
+
+// Tag data of struct
+type Tag struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+// Category data of struct, category has the same shape as tag
+type Category = Tag
+
+// TagCount data of struct for tag with its total articles
+type TagCount struct {
+	Tag
+	Articles int `json:"articles"`
+}
+
+// CategoryCount data of struct for category with its total articles
+type CategoryCount = TagCount
+
+// TaxonomyRequest data of struct for attaching tags or categories into article payload,
+// unknown name is created and its slug is generated from the name
+type TaxonomyRequest struct {
+	Names []string `json:"names" binding:"required,min=1,max=20,dive,min=1,max=50"`
+}
@@ -0,0 +1,55 @@
+package model
+
+import (
+	"time"
+
+	"github.com/willy182/boilerplate-go-cleanarch/src/shared"
+)
+
+const (
+	// TransferCSV format of article import and export as comma separated values with header row
+	TransferCSV = "csv"
+	// TransferNDJSON format of article import and export as one json article per line
+	TransferNDJSON = "ndjson"
+	// ImportMaxRows maximum rows of single article import
+	ImportMaxRows = 10000
+	// ExportBatchSize number of exported articles that is loaded with their tags and categories at once
+	ExportBatchSize = 100
+	// TermSeparator separator of tag and category names inside single csv column
+	TermSeparator = "|"
+)
+
+// TransferFormats list of format that can be used for article import and export
+var TransferFormats = []string{TransferCSV, TransferNDJSON}
+
+// TransferColumns list of csv column of article import and export, import only reads the writable ones
+var TransferColumns = []string{"id", "slug", "title", "summary", "description", "image", "status", "authorId",
+	"created", "modified", "publishedAt", "tags", "categories"}
+
+// ArticleImportRow data of struct for single row of article import, Row is its 1-based position without csv header,
+// Err is filled when the row can not be parsed or validated before it reaches use case
+type ArticleImportRow struct {
+	Row         int        `json:"-"`
+	Err         error      `json:"-"`
+	Title       string     `json:"title" binding:"required,max=100"`
+	Summary     string     `json:"summary" binding:"required,max=250"`
+	Description string     `json:"description" binding:"max=50000"`
+	Image       string     `json:"image" binding:"max=500"`
+	Slug        string     `json:"slug" binding:"max=150"`
+	AuthorID    int        `json:"authorId" binding:"omitempty,min=1"`
+	Status      string     `json:"status"`
+	Created     *time.Time `json:"created"`
+	PublishedAt *time.Time `json:"publishedAt"`
+	Tags        []Tag      `json:"tags"`
+	Categories  []Category `json:"categories"`
+}
+
+// ArticleImportResult data of struct for report of article import,
+// failed rows are keyed by their position in Errors, dry run counts valid rows as imported
+type ArticleImportResult struct {
+	DryRun   bool               `json:"dryRun"`
+	Total    int                `json:"total"`
+	Imported int                `json:"imported"`
+	Failed   int                `json:"failed"`
+	Errors   *shared.MultiError `json:"-"`
+}
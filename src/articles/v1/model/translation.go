@@ -0,0 +1,61 @@
+package model
+
+import "time"
+
+const (
+	// LocaleIndonesian locale of article written in indonesian
+	LocaleIndonesian = "id"
+	// LocaleEnglish locale of article written in english
+	LocaleEnglish = "en"
+	// DefaultLocale locale of content that is stored on the article itself
+	DefaultLocale = LocaleIndonesian
+)
+
+// Locales list of locale that article can be published in, the first one is DefaultLocale
+var Locales = []string{LocaleIndonesian, LocaleEnglish}
+
+// GormArticleTranslation data of struct for translated content of article in single locale
+type GormArticleTranslation struct {
+	ArticleID       int        `gorm:"PRIMARY_KEY;auto_increment:false"`
+	Locale          string     `gorm:"type:varchar(10);PRIMARY_KEY"`
+	Title           string     `gorm:"type:varchar(100);NOT NULL"`
+	Summary         string     `gorm:"type:varchar(250);NOT NULL"`
+	Description     string     `gorm:"type:text"`
+	DescriptionHTML string     `gorm:"type:text"`
+	Created         *time.Time `gorm:"type:timestamp(6) with time zone;NOT NULL"`
+	Modified        *time.Time `gorm:"type:timestamp(6) with time zone"`
+}
+
+// ArticleTranslation data of struct
+type ArticleTranslation struct {
+	ArticleID       int       `json:"articleId"`
+	Locale          string    `json:"locale"`
+	Title           string    `json:"title"`
+	Summary         string    `json:"summary"`
+	Description     string    `json:"description,omitempty"`
+	DescriptionHTML string    `json:"-"`
+	Created         time.Time `json:"created"`
+	Modified        string    `json:"modified,omitempty"`
+}
+
+// ArticleTranslationRequest data of struct for create and replace article translation payload
+type ArticleTranslationRequest struct {
+	Locale      string `json:"locale" binding:"required"`
+	Title       string `json:"title" binding:"required,max=100"`
+	Summary     string `json:"summary" binding:"required,max=250"`
+	Description string `json:"description" binding:"max=50000"`
+}
+
+// Translate function for replacing content of article with its translation,
+// article is modified when either the article or its translation is changed
+func (a *Article) Translate(translation ArticleTranslation) {
+	a.Locale = translation.Locale
+	a.Title = translation.Title
+	a.Summary = translation.Summary
+	a.Description = translation.Description
+	a.DescriptionHTML = translation.DescriptionHTML
+
+	if modified, err := time.Parse(time.RFC3339, translation.Modified); err == nil && modified.After(a.LastModified()) {
+		a.Modified = translation.Modified
+	}
+}
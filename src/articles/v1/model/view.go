@@ -0,0 +1,45 @@
+package model
+
+import "time"
+
+const (
+	// ViewDayFormat format of day that views are counted in
+	ViewDayFormat = "2006-01-02"
+	// ViewBufferSize number of buffered article days that triggers flush before the next interval
+	ViewBufferSize = 1000
+	// PopularDefaultLimit number of popular articles when limit is not requested
+	PopularDefaultLimit = 10
+	// PopularMaxLimit maximum number of popular articles
+	PopularMaxLimit = 50
+)
+
+// PopularWindows mapping of popular window query param into its duration,
+// views are counted per day so window starts at the beginning of its first day and spans up to one day more,
+// e.g. 24h counts views of yesterday and today which is anything between 24h and 48h
+var PopularWindows = map[string]time.Duration{
+	"24h": 24 * time.Hour,
+	"7d":  7 * 24 * time.Hour,
+	"30d": 30 * 24 * time.Hour,
+}
+
+// PopularWindowNames list of popular window in ascending order
+var PopularWindowNames = []string{"24h", "7d", "30d"}
+
+// ArticleView data of struct for number of views of article in single day
+type ArticleView struct {
+	ArticleID int
+	Day       string
+	Views     int64
+}
+
+// PopularParams data of struct for filtering popular articles
+type PopularParams struct {
+	Window string
+	Limit  int
+}
+
+// PopularArticle data of struct for popular article with its views in the window
+type PopularArticle struct {
+	Article
+	Views int64 `json:"views"`
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"sync"
+	"time"
+
+	"github.com/willy182/boilerplate-go-cleanarch/src/articles/v1/model"
+)
+
+// relatedCache in memory cache of related articles per article id, entry expires after ttl
+type relatedCache struct {
+	mu         sync.RWMutex
+	ttl        time.Duration
+	generation int
+	entries    map[int]relatedEntry
+}
+
+// relatedEntry data of struct for cached related articles with its expiry time
+type relatedEntry struct {
+	articles []model.Article
+	expires  time.Time
+}
+
+// newRelatedCache function for creating empty related cache with lifetime of its entries
+func newRelatedCache(ttl time.Duration) *relatedCache {
+	return &relatedCache{ttl: ttl, entries: make(map[int]relatedEntry)}
+}
+
+// get function for getting copy of cached related articles of article id,
+// generation is passed into set so result computed before an invalidation is never cached
+func (c *relatedCache) get(articleID int) ([]model.Article, int, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entry, ok := c.entries[articleID]
+	if !ok || time.Now().After(entry.expires) {
+		return nil, c.generation, false
+	}
+
+	return append([]model.Article(nil), entry.articles...), c.generation, true
+}
+
+// set function for caching related articles of article id when nothing is invalidated since generation
+func (c *relatedCache) set(articleID int, articles []model.Article, generation int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if generation != c.generation {
+		return
+	}
+
+	now := time.Now()
+	for id, entry := range c.entries {
+		if now.After(entry.expires) {
+			delete(c.entries, id)
+		}
+	}
+
+	c.entries[articleID] = relatedEntry{
+		articles: append([]model.Article(nil), articles...),
+		expires:  now.Add(c.ttl),
+	}
+}
+
+// invalidate function for dropping every cached related articles, it is called after any write of article status,
+// deleted, content or tags because the changed article can also enter related articles of another article
+func (c *relatedCache) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	c.entries = make(map[int]relatedEntry)
+}
@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/willy182/boilerplate-go-cleanarch/src/articles/v1/model"
+)
+
+func TestRelatedCache(t *testing.T) {
+	related := []model.Article{{ID: 2}, {ID: 3}}
+
+	tests := []struct {
+		name     string
+		ttl      time.Duration
+		run      func(c *relatedCache)
+		expected []model.Article
+		found    bool
+	}{
+		{
+			name:  "miss",
+			ttl:   time.Minute,
+			run:   func(c *relatedCache) {},
+			found: false,
+		},
+		{
+			name: "hit",
+			ttl:  time.Minute,
+			run: func(c *relatedCache) {
+				_, generation, _ := c.get(1)
+				c.set(1, related, generation)
+			},
+			expected: related,
+			found:    true,
+		},
+		{
+			name: "expired",
+			ttl:  -time.Second,
+			run: func(c *relatedCache) {
+				_, generation, _ := c.get(1)
+				c.set(1, related, generation)
+			},
+			found: false,
+		},
+		{
+			name: "invalidated",
+			ttl:  time.Minute,
+			run: func(c *relatedCache) {
+				_, generation, _ := c.get(1)
+				c.set(1, related, generation)
+				c.invalidate()
+			},
+			found: false,
+		},
+		{
+			name: "computed before invalidation",
+			ttl:  time.Minute,
+			run: func(c *relatedCache) {
+				_, generation, _ := c.get(1)
+				c.invalidate()
+				c.set(1, related, generation)
+			},
+			found: false,
+		},
+		{
+			name: "computed after invalidation",
+			ttl:  time.Minute,
+			run: func(c *relatedCache) {
+				c.invalidate()
+				_, generation, _ := c.get(1)
+				c.set(1, related, generation)
+			},
+			expected: related,
+			found:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newRelatedCache(tt.ttl)
+			tt.run(c)
+
+			articles, _, found := c.get(1)
+			if found != tt.found {
+				t.Fatalf("found = %v, expected %v", found, tt.found)
+			}
+
+			if len(articles) != len(tt.expected) {
+				t.Fatalf("articles = %v, expected %v", articles, tt.expected)
+			}
+			for i := range articles {
+				if articles[i].ID != tt.expected[i].ID {
+					t.Errorf("articles[%d].ID = %d, expected %d", i, articles[i].ID, tt.expected[i].ID)
+				}
+			}
+		})
+	}
+}
+
+func TestRelatedCacheCopy(t *testing.T) {
+	c := newRelatedCache(time.Minute)
+
+	articles := []model.Article{{ID: 2}}
+	_, generation, _ := c.get(1)
+	c.set(1, articles, generation)
+	articles[0].ID = 5
+
+	cached, _, _ := c.get(1)
+	cached[0].ID = 6
+
+	if cached, _, _ = c.get(1); cached[0].ID != 2 {
+		t.Errorf("cached article id = %d, expected 2", cached[0].ID)
+	}
+}
+
+func TestRelatedCacheInvalidateEveryEntry(t *testing.T) {
+	c := newRelatedCache(time.Minute)
+
+	_, generation, _ := c.get(1)
+	c.set(1, []model.Article{{ID: 2}}, generation)
+	c.set(3, []model.Article{{ID: 4}}, generation)
+	c.invalidate()
+
+	for _, id := range []int{1, 3} {
+		if _, _, found := c.get(id); found {
+			t.Errorf("related articles of %d are still cached", id)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/willy182/boilerplate-go-cleanarch/src/articles/v1/model"
 )
@@ -14,8 +15,40 @@ type ResultRepository struct {
 
 // Repository interface for article repository
 type Repository interface {
-	Save(ctx context.Context, param *model.GormArticle) <-chan error
-	GetByID(ctx context.Context, ID int) <-chan ResultRepository
-	// GetAll(ctx context.Context, param model.Article) <-chan ResultRepository
-	// GetTotal(ctx context.Context, param model.Article) <-chan ResultRepository
+	Save(ctx context.Context, param *model.GormArticle) <-chan ResultRepository
+	GetByID(ctx context.Context, ID int, fields ...string) <-chan ResultRepository
+	GetBySlug(ctx context.Context, slug string, fields ...string) <-chan ResultRepository
+	GetAvailableSlug(ctx context.Context, base string, articleID int) <-chan ResultRepository
+	Delete(ctx context.Context, ID int) <-chan error
+	Restore(ctx context.Context, ID int) <-chan ResultRepository
+	UpdateStatus(ctx context.Context, ID int, status string, version int) <-chan ResultRepository
+	Batch(ctx context.Context, items []model.ArticleBatchItem, atomic bool) <-chan ResultRepository
+	UpdateSchedule(ctx context.Context, ID int, publishAt, unpublishAt *time.Time, version int) <-chan ResultRepository
+	UpdateImage(ctx context.Context, ID int, image string, variants map[string]string) <-chan ResultRepository
+	ApplySchedule(ctx context.Context, batchSize int) <-chan ResultRepository
+	AttachTags(ctx context.Context, articleID int, tags []model.Tag) <-chan error
+	DetachTag(ctx context.Context, articleID int, slug string) <-chan error
+	GetTagsByArticleIDs(ctx context.Context, articleIDs []int) <-chan ResultRepository
+	GetTagCounts(ctx context.Context, statuses []string) <-chan ResultRepository
+	AttachCategories(ctx context.Context, articleID int, categories []model.Category) <-chan error
+	DetachCategory(ctx context.Context, articleID int, slug string) <-chan error
+	GetCategoriesByArticleIDs(ctx context.Context, articleIDs []int) <-chan ResultRepository
+	GetCategoryCounts(ctx context.Context, statuses []string) <-chan ResultRepository
+	SaveTranslation(ctx context.Context, param *model.GormArticleTranslation) <-chan ResultRepository
+	GetTranslations(ctx context.Context, articleID int) <-chan ResultRepository
+	GetTranslationsByArticleIDs(ctx context.Context, articleIDs []int, locales []string) <-chan ResultRepository
+	GetRevisions(ctx context.Context, articleID int) <-chan ResultRepository
+	GetRevision(ctx context.Context, articleID, revision int) <-chan ResultRepository
+	GetAll(ctx context.Context, params model.ArticleParams) <-chan ResultRepository
+	GetTotal(ctx context.Context, params model.ArticleParams) <-chan ResultRepository
+	GetLastModified(ctx context.Context, params model.ArticleParams) <-chan ResultRepository
+	GetAllByCursor(ctx context.Context, params model.ArticleParams) <-chan ResultRepository
+	GetSitemapIndex(ctx context.Context, pageSize int) <-chan ResultRepository
+	GetSitemapEntries(ctx context.Context, after, pageSize int) <-chan ResultRepository
+	Search(ctx context.Context, params model.ArticleParams) <-chan ResultRepository
+	SearchTotal(ctx context.Context, params model.ArticleParams) <-chan ResultRepository
+	Export(ctx context.Context, params model.ArticleParams) <-chan ResultRepository
+	GetRelated(ctx context.Context, articleID, limit int) <-chan ResultRepository
+	IncrementViews(ctx context.Context, views []model.ArticleView) <-chan error
+	GetPopular(ctx context.Context, since string, limit int) <-chan ResultRepository
 }
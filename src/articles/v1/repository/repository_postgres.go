@@ -3,7 +3,9 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/willy182/boilerplate-go-cleanarch/src/articles/v1/model"
@@ -15,28 +17,82 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
-const tableName = "articles"
+const (
+	tableName            = "articles"
+	revisionTableName    = "article_revisions"
+	slugHistoryTableName = "article_slug_histories"
+	articleColumns       = "id, title, summary, description, image, created, modified, version, deleted, status, published_at, publish_at, unpublish_at, slug, author_id, image_variants, description_html, comment_count"
+	revisionColumns      = "article_id, revision, author, title, summary, description, image, status, slug, author_id, created"
+
+	// revisionSnapshot select list of article row into revisionColumns, the revision author is its only argument
+	revisionSnapshot = "id, version, ?, title, summary, description, image, status, slug, author_id, now()"
+)
+
+// articleSortColumns mapping of sort field into its column
+var articleSortColumns = map[string]string{
+	"id":          "id",
+	"title":       "title",
+	"created":     "created",
+	"modified":    "modified",
+	"publishedAt": "published_at",
+}
+
+// articleFieldColumns mapping of article field into its column
+var articleFieldColumns = map[string]string{
+	"id":            "id",
+	"slug":          "slug",
+	"title":         "title",
+	"summary":       "summary",
+	"description":   "description",
+	"image":         "image",
+	"created":       "created",
+	"modified":      "modified",
+	"version":       "version",
+	"deleted":       "deleted",
+	"status":        "status",
+	"publishedAt":   "published_at",
+	"publishAt":     "publish_at",
+	"unpublishAt":   "unpublish_at",
+	"authorId":      "author_id",
+	"imageVariants": "image_variants",
+	"commentCount":  "comment_count",
+}
+
+// likeEscaper escape wildcard character of LIKE pattern
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// rowScanner abstraction of *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
 
 // postgresArticleRepo struct
 type postgresArticleRepo struct {
-	read  *gorm.DB
-	write *gorm.DB
+	read    *gorm.DB
+	write   *gorm.DB
+	related *relatedCache
 }
 
 // NewPostgresArticleRepository article repository postgres handler
 func NewPostgresArticleRepository(read, write *gorm.DB) Repository {
 	// postgresConfig.InitDB()
 	return &postgresArticleRepo{
-		read:  read,
-		write: write,
+		read:    read,
+		write:   write,
+		related: newRelatedCache(model.RelatedCacheTTL),
 	}
 }
 
-// Save function, for save article object into database
-func (r *postgresArticleRepo) Save(ctx context.Context, param *model.GormArticle) <-chan error {
+// Save function, for save article object into database using single upsert statement,
+// the persisted row including generated id and timestamps is returned as model.Article
+func (r *postgresArticleRepo) Save(ctx context.Context, param *model.GormArticle) <-chan ResultRepository {
 	ctxRepo := "ArticleRepositorySave"
 
-	output := make(chan error)
+	// rendered html is derived from the markdown description on every save so both never drift apart,
+	// it is rendered before the transaction so the article row is not locked while rendering
+	param.DescriptionHTML = shared.RenderMarkdown(param.Description)
+
+	output := make(chan ResultRepository)
 
 	go func() {
 		// begin
@@ -47,47 +103,137 @@ func (r *postgresArticleRepo) Save(ctx context.Context, param *model.GormArticle
 				message := fmt.Sprintf("panic: %v", r)
 				utils.Log(log.ErrorLevel, message, ctxRepo, "recover_repository_save")
 				tx.Rollback()
-				output <- fmt.Errorf(message)
+				output <- ResultRepository{Error: fmt.Errorf(message)}
 			}
 			close(output)
 		}()
 
 		if err := tx.Error; err != nil {
 			utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "tx_error")
-			output <- err
-		}
-
-		// Select ID
-		var id int
-		row := r.read.Table(tableName).Where("id = ?", param.ID).Select("id").Row()
-		row.Scan(&id)
-
-		var errStmt error
-
-		// force checking for auto increment number to insert or update
-		if id > 0 {
-			errStmt = tx.Table(tableName).Where("id = ?", param.ID).Updates(&param).Error
-		} else {
-			errStmt = tx.Table(tableName).Save(&param).Error
+			output <- ResultRepository{Error: err}
+			return
 		}
 
-		if errStmt != nil {
-			utils.Log(log.ErrorLevel, errStmt.Error(), ctxRepo, "save_or_update_article")
+		article, err := r.saveArticle(ctx, tx, ctxRepo, param)
+		if err != nil {
 			tx.Rollback()
-			output <- errStmt
+			output <- ResultRepository{Error: err}
 			return
 		}
 
-		tx.Commit()
+		if err := tx.Commit().Error; err != nil {
+			utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "tx_commit")
+			output <- ResultRepository{Error: err}
+			return
+		}
+		r.related.invalidate()
 
-		output <- nil
+		output <- ResultRepository{Result: article}
 	}()
 
 	return output
 }
 
-// Load function, for find Master Status by its primary ID
-func (r *postgresArticleRepo) GetByID(ctx context.Context, id int) <-chan ResultRepository {
+// saveArticle function, for save article object inside transaction tx together with its slug history and revision,
+// the caller owns tx and must roll it back on error, param.DescriptionHTML must already be rendered
+func (r *postgresArticleRepo) saveArticle(ctx context.Context, tx *gorm.DB, ctxRepo string, param *model.GormArticle) (model.Article, error) {
+	// current slug is locked and kept to be moved into slug history when it is changed,
+	// soft deleted article is not found rather than a version conflict
+	var (
+		oldSlug string
+		deleted bool
+	)
+	if param.ID > 0 {
+		errSlug := tx.Raw(`SELECT slug, deleted IS NOT NULL FROM `+tableName+` WHERE id = ? FOR UPDATE`, param.ID).Row().Scan(&oldSlug, &deleted)
+		if errSlug != nil && errSlug != sql.ErrNoRows {
+			utils.Log(log.ErrorLevel, errSlug.Error(), ctxRepo, "lock_article_slug")
+			return model.Article{}, errSlug
+		}
+
+		if deleted {
+			return model.Article{}, shared.ErrDataNotFound
+		}
+	}
+
+	// zero id is left to the sequence, otherwise the row is inserted or updated atomically by its id,
+	// the update only happens when param.Version is still the stored version and never changes the status nor published_at,
+	// empty slug keeps the stored one, author is always replaced, image variants are dropped when the image is replaced
+	var (
+		row      *sql.Row
+		inserted bool
+	)
+	if param.ID > 0 {
+		row = tx.Raw(`INSERT INTO `+tableName+` (id, title, summary, description, description_html, image, created, modified, status, slug, author_id, published_at)
+			VALUES (?, ?, ?, ?, ?, ?, COALESCE(?, now()), ?, COALESCE(NULLIF(?, ''), 'draft'), ?, ?, ?)
+			ON CONFLICT (id) DO UPDATE SET
+				title = EXCLUDED.title,
+				summary = EXCLUDED.summary,
+				description = EXCLUDED.description,
+				description_html = EXCLUDED.description_html,
+				image = EXCLUDED.image,
+				image_variants = CASE WHEN EXCLUDED.image = `+tableName+`.image THEN `+tableName+`.image_variants END,
+				slug = COALESCE(NULLIF(EXCLUDED.slug, ''), `+tableName+`.slug),
+				author_id = EXCLUDED.author_id,
+				modified = COALESCE(EXCLUDED.modified, now()),
+				version = `+tableName+`.version + 1
+			WHERE `+tableName+`.version = ? AND `+tableName+`.deleted IS NULL
+			RETURNING `+articleColumns+`, xmax = 0`,
+			param.ID, param.Title, param.Summary, param.Description, param.DescriptionHTML, param.Image, param.Created, param.Modified,
+			param.Status, param.Slug, param.AuthorID, param.PublishedAt, param.Version).Row()
+	} else {
+		row = tx.Raw(`INSERT INTO `+tableName+` (title, summary, description, description_html, image, created, modified, status, slug, author_id, published_at)
+			VALUES (?, ?, ?, ?, ?, COALESCE(?, now()), ?, COALESCE(NULLIF(?, ''), 'draft'), ?, ?, ?)
+			RETURNING `+articleColumns+`, false`,
+			param.Title, param.Summary, param.Description, param.DescriptionHTML, param.Image, param.Created, param.Modified,
+			param.Status, param.Slug, param.AuthorID, param.PublishedAt).Row()
+	}
+
+	article, errStmt := scanArticle(row, &inserted)
+	if errStmt == sql.ErrNoRows {
+		// no row is returned when the conditional update is skipped
+		return article, shared.ErrDataConflict
+	}
+
+	if isUniqueViolation(errStmt) {
+		// the slug is taken by concurrent save of another article
+		return article, model.ErrSlugUsed
+	}
+
+	if errStmt != nil {
+		utils.Log(log.ErrorLevel, errStmt.Error(), ctxRepo, "save_or_update_article")
+		return article, errStmt
+	}
+
+	// row inserted with explicit id is never seen by the sequence, it is moved past the id so later insert does not collide
+	if inserted {
+		errSeq := tx.Exec(`SELECT setval(pg_get_serial_sequence('` + tableName + `', 'id'),
+			GREATEST(max(id), nextval(pg_get_serial_sequence('` + tableName + `', 'id')))) FROM ` + tableName).Error
+		if errSeq != nil {
+			utils.Log(log.ErrorLevel, errSeq.Error(), ctxRepo, "advance_article_sequence")
+			return article, errSeq
+		}
+	}
+
+	if errHistory := r.saveSlugHistory(tx, article.ID, oldSlug, article.Slug); errHistory != nil {
+		utils.Log(log.ErrorLevel, errHistory.Error(), ctxRepo, "save_article_slug_history")
+		return article, errHistory
+	}
+
+	// snapshot of every saved state, the saved version is used as revision number
+	errRevision := tx.Exec(`INSERT INTO `+revisionTableName+` (`+revisionColumns+`)
+		SELECT `+revisionSnapshot+` FROM `+tableName+` WHERE id = ?`,
+		shared.CallerFromContext(ctx).Name, article.ID).Error
+	if errRevision != nil {
+		utils.Log(log.ErrorLevel, errRevision.Error(), ctxRepo, "save_article_revision")
+		return article, errRevision
+	}
+
+	return article, nil
+}
+
+// Load function, for find Master Status by its primary ID,
+// only columns of fields and model.ArticleRequiredFields are loaded when fields is not empty
+func (r *postgresArticleRepo) GetByID(ctx context.Context, id int, fields ...string) <-chan ResultRepository {
 	ctxRepo := "ArticleRepositoryGetByID"
 
 	output := make(chan ResultRepository)
@@ -102,35 +248,538 @@ func (r *postgresArticleRepo) GetByID(ctx context.Context, id int) <-chan Result
 			close(output)
 		}()
 
-		var (
-			article   model.Article
-			desc, img sql.NullString
-			modified  pq.NullTime
-		)
-
 		if e := r.read.Table(tableName).Where("id = ?", id).Select("id").Error; e != nil && e.Error() != shared.ErrorRecordNotFound {
 			utils.Log(log.ErrorLevel, e.Error(), ctxRepo, "recover_repository_get_by_id")
 			output <- ResultRepository{Error: e}
 			return
 		}
 
-		row := r.read.Table(tableName).Where("id = ?", id).Select("id, title, summary, description, image, created, modified").Row()
-		row.Scan(&article.ID, &article.Title, &article.Summary, &desc, &img, &article.Created, &modified)
+		columns := selectColumns(fields)
+		row := r.read.Table(tableName).Where("id = ? AND deleted IS NULL", id).Select(strings.Join(columns, ", ")).Row()
+		article, err := scanArticleColumns(row, columns)
+		if err == sql.ErrNoRows {
+			output <- ResultRepository{Error: shared.ErrDataNotFound}
+			return
+		}
+
+		if err != nil {
+			utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "scan_article")
+			output <- ResultRepository{Error: err}
+			return
+		}
+
+		output <- ResultRepository{Result: article}
+	}()
+
+	return output
+}
+
+// Delete function, for soft delete article object by its primary ID
+func (r *postgresArticleRepo) Delete(ctx context.Context, id int) <-chan error {
+	ctxRepo := "ArticleRepositoryDelete"
+
+	output := make(chan error)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxRepo, "recover_repository_delete")
+				output <- fmt.Errorf(message)
+			}
+			close(output)
+		}()
+
+		if err := r.deleteArticle(ctx, r.write, ctxRepo, id); err != nil {
+			output <- err
+			return
+		}
+		r.related.invalidate()
+
+		output <- nil
+	}()
+
+	return output
+}
 
-		if desc.Valid {
-			article.Description = desc.String
+// deleteArticle function, for soft delete article by its primary ID using db, which can be a transaction,
+// the deleted state is written as revision
+func (r *postgresArticleRepo) deleteArticle(ctx context.Context, db *gorm.DB, ctxRepo string, id int) error {
+	var deletedID int
+	err := db.Raw(withRevision(`UPDATE `+tableName+` SET deleted = now(), modified = now(), version = version + 1
+		WHERE id = ? AND deleted IS NULL`, "id"), id, shared.CallerFromContext(ctx).Name).Row().Scan(&deletedID)
+	if err == sql.ErrNoRows {
+		return shared.ErrDataNotFound
+	}
+
+	if err != nil {
+		utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "delete_article")
+		return err
+	}
+
+	return nil
+}
+
+// Restore function, for restore soft deleted article object by its primary ID
+func (r *postgresArticleRepo) Restore(ctx context.Context, id int) <-chan ResultRepository {
+	ctxRepo := "ArticleRepositoryRestore"
+
+	output := make(chan ResultRepository)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxRepo, "recover_repository_restore")
+				output <- ResultRepository{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		row := r.write.Raw(withRevision(`UPDATE `+tableName+` SET deleted = NULL, modified = now(), version = version + 1
+			WHERE id = ? AND deleted IS NOT NULL`, articleColumns), id, shared.CallerFromContext(ctx).Name).Row()
+		article, err := scanArticle(row)
+		if err == sql.ErrNoRows {
+			output <- ResultRepository{Error: shared.ErrDataNotFound}
+			return
 		}
 
-		if img.Valid {
-			article.Image = img.String
+		if err != nil {
+			utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "restore_article")
+			output <- ResultRepository{Error: err}
+			return
 		}
+		r.related.invalidate()
+
+		output <- ResultRepository{Result: article}
+	}()
+
+	return output
+}
 
-		if modified.Valid {
-			article.Modified = modified.Time.Format(time.RFC3339)
+// UpdateStatus function, for change article status when version is still the stored version,
+// published_at is stamped on the first time article is published
+func (r *postgresArticleRepo) UpdateStatus(ctx context.Context, id int, status string, version int) <-chan ResultRepository {
+	ctxRepo := "ArticleRepositoryUpdateStatus"
+
+	output := make(chan ResultRepository)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxRepo, "recover_repository_update_status")
+				output <- ResultRepository{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		article, err := r.updateStatus(ctx, r.write, ctxRepo, id, status, version)
+		if err != nil {
+			output <- ResultRepository{Error: err}
+			return
 		}
+		r.related.invalidate()
 
 		output <- ResultRepository{Result: article}
 	}()
 
 	return output
 }
+
+// updateStatus function, for change status of article using db, which can be a transaction,
+// the status is only changed when version is still the stored version and the change is written as revision of the caller
+func (r *postgresArticleRepo) updateStatus(ctx context.Context, db *gorm.DB, ctxRepo string, id int, status string, version int) (model.Article, error) {
+	row := db.Raw(withRevision(`UPDATE `+tableName+` SET
+			status = ?,
+			published_at = CASE WHEN CAST(? AS boolean) THEN COALESCE(published_at, now()) ELSE published_at END,
+			modified = now(),
+			version = version + 1
+		WHERE id = ? AND version = ? AND deleted IS NULL`, articleColumns),
+		status, status == model.StatusPublished, id, version, shared.CallerFromContext(ctx).Name).Row()
+	article, err := scanArticle(row)
+	if err == sql.ErrNoRows {
+		return article, shared.ErrDataConflict
+	}
+
+	if err != nil {
+		utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "update_status_article")
+		return article, err
+	}
+
+	return article, nil
+}
+
+// GetAll function, for find list of article by its params
+func (r *postgresArticleRepo) GetAll(ctx context.Context, params model.ArticleParams) <-chan ResultRepository {
+	ctxRepo := "ArticleRepositoryGetAll"
+
+	output := make(chan ResultRepository)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxRepo, "recover_repository_get_all")
+				output <- ResultRepository{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		columns := selectColumns(params.Fields)
+		db := r.filter(r.read.Table(tableName), params).Select(strings.Join(columns, ", "))
+
+		for _, sort := range params.Sort {
+			order := articleSortColumns[sort.Field]
+			if sort.Desc {
+				order += " DESC"
+			}
+			db = db.Order(order)
+		}
+		db = db.Order("id DESC")
+
+		if params.Limit > 0 {
+			db = db.Offset((params.Page - 1) * params.Limit).Limit(params.Limit)
+		}
+
+		rows, err := db.Rows()
+		if err != nil {
+			utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "query_articles")
+			output <- ResultRepository{Error: err}
+			return
+		}
+		defer rows.Close()
+
+		articles := []model.Article{}
+		for rows.Next() {
+			article, err := scanArticleColumns(rows, columns)
+			if err != nil {
+				utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "scan_article")
+				output <- ResultRepository{Error: err}
+				return
+			}
+			articles = append(articles, article)
+		}
+
+		output <- ResultRepository{Result: articles}
+	}()
+
+	return output
+}
+
+// GetTotal function, for count total article by its params
+func (r *postgresArticleRepo) GetTotal(ctx context.Context, params model.ArticleParams) <-chan ResultRepository {
+	ctxRepo := "ArticleRepositoryGetTotal"
+
+	output := make(chan ResultRepository)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxRepo, "recover_repository_get_total")
+				output <- ResultRepository{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		var total int
+		if err := r.filter(r.read.Table(tableName), params).Count(&total).Error; err != nil {
+			utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "count_articles")
+			output <- ResultRepository{Error: err}
+			return
+		}
+
+		output <- ResultRepository{Result: total}
+	}()
+
+	return output
+}
+
+// GetLastModified function, for find the last time any article of params scope is changed, whatever its status
+// or deletion so article leaving the scope is also seen, zero time is returned when there is no article
+func (r *postgresArticleRepo) GetLastModified(ctx context.Context, params model.ArticleParams) <-chan ResultRepository {
+	ctxRepo := "ArticleRepositoryGetLastModified"
+
+	output := make(chan ResultRepository)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxRepo, "recover_repository_get_last_modified")
+				output <- ResultRepository{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		params.Status = nil
+		params.IncludeDeleted = true
+
+		var lastModified pq.NullTime
+		row := r.filter(r.read.Table(tableName), params).Select("max(COALESCE(modified, created))").Row()
+		if err := row.Scan(&lastModified); err != nil {
+			utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "max_modified_articles")
+			output <- ResultRepository{Error: err}
+			return
+		}
+
+		output <- ResultRepository{Result: lastModified.Time}
+	}()
+
+	return output
+}
+
+// GetAllByCursor function, for find list of article by seeking on (created, id) from params cursor,
+// it returns at most params.Limit + 1 rows ordered by newest first to let caller know whether there is more page
+func (r *postgresArticleRepo) GetAllByCursor(ctx context.Context, params model.ArticleParams) <-chan ResultRepository {
+	ctxRepo := "ArticleRepositoryGetAllByCursor"
+
+	output := make(chan ResultRepository)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxRepo, "recover_repository_get_all_by_cursor")
+				output <- ResultRepository{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		cursor := params.Cursor
+		if cursor == nil {
+			cursor = new(model.ArticleCursor)
+		}
+
+		columns := selectColumns(params.Fields)
+		db := r.filter(r.read.Table(tableName), params).Select(strings.Join(columns, ", "))
+
+		switch {
+		case cursor.IsFirst():
+			db = db.Order("created DESC, id DESC")
+		case cursor.Prev:
+			db = db.Where("(created, id) > (?, ?)", cursor.Created, cursor.ID).Order("created ASC, id ASC")
+		default:
+			db = db.Where("(created, id) < (?, ?)", cursor.Created, cursor.ID).Order("created DESC, id DESC")
+		}
+
+		rows, err := db.Limit(params.Limit + 1).Rows()
+		if err != nil {
+			utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "query_articles")
+			output <- ResultRepository{Error: err}
+			return
+		}
+		defer rows.Close()
+
+		articles := []model.Article{}
+		for rows.Next() {
+			article, err := scanArticleColumns(rows, columns)
+			if err != nil {
+				utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "scan_article")
+				output <- ResultRepository{Error: err}
+				return
+			}
+			articles = append(articles, article)
+		}
+
+		// backward seek is queried in ascending order, reverse it back into newest first
+		if cursor.Prev {
+			for i, j := 0, len(articles)-1; i < j; i, j = i+1, j-1 {
+				articles[i], articles[j] = articles[j], articles[i]
+			}
+		}
+
+		output <- ResultRepository{Result: articles}
+	}()
+
+	return output
+}
+
+// filter function, for applying article params as where clause
+func (r *postgresArticleRepo) filter(db *gorm.DB, params model.ArticleParams) *gorm.DB {
+	if !params.IncludeDeleted {
+		db = db.Where("deleted IS NULL")
+	}
+
+	if params.Query != "" {
+		query := "%" + likeEscaper.Replace(params.Query) + "%"
+		db = db.Where("(title ILIKE ? OR summary ILIKE ?)", query, query)
+	}
+
+	if len(params.Status) > 0 {
+		db = db.Where("status IN (?)", params.Status)
+	}
+
+	if params.AuthorID > 0 {
+		db = db.Where("author_id = ?", params.AuthorID)
+	}
+
+	if params.Tag != "" {
+		db = db.Where(tableName+".id IN (SELECT "+tagTables.joinTable+".article_id FROM "+tagTables.joinTable+
+			" JOIN "+tagTables.table+" ON "+tagTables.table+".id = "+tagTables.joinTable+"."+tagTables.column+
+			" WHERE "+tagTables.table+".slug = ?)", params.Tag)
+	}
+
+	if params.Category != "" {
+		db = db.Where(tableName+".id IN (SELECT "+categoryTables.joinTable+".article_id FROM "+categoryTables.joinTable+
+			" JOIN "+categoryTables.table+" ON "+categoryTables.table+".id = "+categoryTables.joinTable+"."+categoryTables.column+
+			" WHERE "+categoryTables.table+".slug = ?)", params.Category)
+	}
+
+	if params.CreatedFrom != nil {
+		db = db.Where("created >= ?", params.CreatedFrom)
+	}
+
+	if params.CreatedTo != nil {
+		db = db.Where("created <= ?", params.CreatedTo)
+	}
+
+	if params.ModifiedFrom != nil {
+		db = db.Where("modified >= ?", params.ModifiedFrom)
+	}
+
+	if params.ModifiedTo != nil {
+		db = db.Where("modified <= ?", params.ModifiedTo)
+	}
+
+	return db
+}
+
+// selectColumns function, for getting columns of requested fields in the same order as articleColumns,
+// empty fields means every column
+func selectColumns(fields []string) []string {
+	columns := strings.Split(articleColumns, ", ")
+	if len(fields) == 0 {
+		return columns
+	}
+
+	requested := make(map[string]bool)
+	for _, field := range fields {
+		requested[articleFieldColumns[field]] = true
+	}
+
+	for _, field := range model.ArticleRequiredFields {
+		requested[articleFieldColumns[field]] = true
+	}
+
+	// rendered html is only an alternative format of description
+	requested["description_html"] = requested["description"]
+
+	selected := make([]string, 0, len(requested))
+	for _, column := range columns {
+		if requested[column] {
+			selected = append(selected, column)
+		}
+	}
+
+	return selected
+}
+
+// scanArticle function, for scanning single article row of articleColumns, extra destination is scanned after article columns
+func scanArticle(row rowScanner, extra ...interface{}) (model.Article, error) {
+	return scanArticleColumns(row, strings.Split(articleColumns, ", "), extra...)
+}
+
+// scanArticleColumns function, for scanning single article row of the given columns, extra destination is scanned after them
+func scanArticleColumns(row rowScanner, columns []string, extra ...interface{}) (model.Article, error) {
+	var (
+		article                        model.Article
+		desc, descHTML, img            sql.NullString
+		modified, deleted, publishedAt pq.NullTime
+		publishAt, unpublishAt         pq.NullTime
+		authorID                       sql.NullInt64
+		imgVariants                    []byte
+	)
+
+	dest := make([]interface{}, 0, len(columns)+len(extra))
+	for _, column := range columns {
+		switch column {
+		case "id":
+			dest = append(dest, &article.ID)
+		case "title":
+			dest = append(dest, &article.Title)
+		case "summary":
+			dest = append(dest, &article.Summary)
+		case "description":
+			dest = append(dest, &desc)
+		case "image":
+			dest = append(dest, &img)
+		case "created":
+			dest = append(dest, &article.Created)
+		case "modified":
+			dest = append(dest, &modified)
+		case "version":
+			dest = append(dest, &article.Version)
+		case "deleted":
+			dest = append(dest, &deleted)
+		case "status":
+			dest = append(dest, &article.Status)
+		case "published_at":
+			dest = append(dest, &publishedAt)
+		case "publish_at":
+			dest = append(dest, &publishAt)
+		case "unpublish_at":
+			dest = append(dest, &unpublishAt)
+		case "slug":
+			dest = append(dest, &article.Slug)
+		case "author_id":
+			dest = append(dest, &authorID)
+		case "image_variants":
+			dest = append(dest, &imgVariants)
+		case "description_html":
+			dest = append(dest, &descHTML)
+		case "comment_count":
+			dest = append(dest, &article.CommentCount)
+		}
+	}
+
+	err := row.Scan(append(dest, extra...)...)
+	if err != nil {
+		return article, err
+	}
+
+	if desc.Valid {
+		article.Description = desc.String
+	}
+
+	if descHTML.Valid {
+		article.DescriptionHTML = descHTML.String
+	}
+
+	if img.Valid {
+		article.Image = img.String
+	}
+
+	if modified.Valid {
+		article.Modified = modified.Time.Format(time.RFC3339)
+	}
+
+	if deleted.Valid {
+		article.Deleted = deleted.Time.Format(time.RFC3339)
+	}
+
+	if publishedAt.Valid {
+		article.PublishedAt = publishedAt.Time.Format(time.RFC3339)
+	}
+
+	if publishAt.Valid {
+		article.PublishAt = publishAt.Time.Format(time.RFC3339)
+	}
+
+	if unpublishAt.Valid {
+		article.UnpublishAt = unpublishAt.Time.Format(time.RFC3339)
+	}
+
+	if authorID.Valid {
+		article.AuthorID = int(authorID.Int64)
+	}
+
+	if len(imgVariants) > 0 {
+		if err := json.Unmarshal(imgVariants, &article.ImageVariants); err != nil {
+			return article, err
+		}
+	}
+
+	return article, nil
+}
@@ -0,0 +1,174 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/willy182/boilerplate-go-cleanarch/src/articles/v1/model"
+	"github.com/willy182/boilerplate-go-cleanarch/src/shared"
+	"github.com/willy182/boilerplate-go-cleanarch/utils"
+
+	"github.com/jinzhu/gorm"
+	log "github.com/sirupsen/logrus"
+)
+
+// Batch function, for applying batch items in order inside single transaction, result of each item is returned in the same order,
+// atomic batch is rolled back on the first failed item, otherwise every item runs in its own savepoint so failed item is skipped
+func (r *postgresArticleRepo) Batch(ctx context.Context, items []model.ArticleBatchItem, atomic bool) <-chan ResultRepository {
+	ctxRepo := "ArticleRepositoryBatch"
+
+	// descriptions are rendered before the transaction the same way as Save
+	for _, item := range items {
+		if item.Article != nil {
+			item.Article.DescriptionHTML = shared.RenderMarkdown(item.Article.Description)
+		}
+	}
+
+	output := make(chan ResultRepository)
+
+	go func() {
+		// begin
+		tx := r.write.Begin()
+
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxRepo, "recover_repository_batch")
+				tx.Rollback()
+				output <- ResultRepository{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		if err := tx.Error; err != nil {
+			utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "tx_error")
+			output <- ResultRepository{Error: err}
+			return
+		}
+
+		results := make([]model.ArticleBatchResult, 0, len(items))
+		for _, item := range items {
+			if !atomic {
+				if err := tx.Exec(`SAVEPOINT batch_item`).Error; err != nil {
+					utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "savepoint")
+					tx.Rollback()
+					output <- ResultRepository{Error: err}
+					return
+				}
+			}
+
+			result := model.ArticleBatchResult{Index: item.Index, Action: item.Action, ID: item.ID}
+			article, err := r.applyBatchItem(ctx, tx, ctxRepo, item)
+			if err != nil {
+				result.Err = err
+				results = append(results, result)
+
+				if atomic {
+					tx.Rollback()
+					output <- ResultRepository{Result: rolledBack(results, items)}
+					return
+				}
+
+				if errSavepoint := tx.Exec(`ROLLBACK TO SAVEPOINT batch_item`).Error; errSavepoint != nil {
+					utils.Log(log.ErrorLevel, errSavepoint.Error(), ctxRepo, "rollback_savepoint")
+					tx.Rollback()
+					output <- ResultRepository{Error: errSavepoint}
+					return
+				}
+				continue
+			}
+
+			if article != nil {
+				result.ID = article.ID
+			}
+			result.Applied = true
+			result.Article = article
+			results = append(results, result)
+		}
+
+		if err := tx.Commit().Error; err != nil {
+			utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "tx_commit")
+			output <- ResultRepository{Error: err}
+			return
+		}
+
+		for _, result := range results {
+			if result.Applied {
+				r.related.invalidate()
+				break
+			}
+		}
+
+		output <- ResultRepository{Result: results}
+	}()
+
+	return output
+}
+
+// applyBatchItem function, for applying single batch item inside transaction tx,
+// article is reloaded after its tags and categories are attached so the returned version is the stored one
+func (r *postgresArticleRepo) applyBatchItem(ctx context.Context, tx *gorm.DB, ctxRepo string, item model.ArticleBatchItem) (*model.Article, error) {
+	switch item.Action {
+	case model.BatchDelete:
+		return nil, r.deleteArticle(ctx, tx, ctxRepo, item.ID)
+
+	case model.BatchStatus:
+		article, err := r.updateStatus(ctx, tx, ctxRepo, item.ID, item.Status, item.Version)
+		if err != nil {
+			return nil, err
+		}
+		return &article, nil
+
+	case model.BatchCreate, model.BatchUpdate:
+		article, err := r.saveArticle(ctx, tx, ctxRepo, item.Article)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(item.Tags) == 0 && len(item.Categories) == 0 {
+			return &article, nil
+		}
+
+		if len(item.Tags) > 0 {
+			if err := r.attachTerms(ctx, tx, ctxRepo, tagTables, article.ID, item.Tags); err != nil {
+				return nil, err
+			}
+		}
+
+		if len(item.Categories) > 0 {
+			if err := r.attachTerms(ctx, tx, ctxRepo, categoryTables, article.ID, item.Categories); err != nil {
+				return nil, err
+			}
+		}
+
+		article, err = scanArticle(tx.Raw(`SELECT `+articleColumns+` FROM `+tableName+` WHERE id = ?`, article.ID).Row())
+		if err != nil {
+			utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "reload_article")
+			return nil, err
+		}
+		return &article, nil
+
+	default:
+		return nil, fmt.Errorf("unknown batch action %q", item.Action)
+	}
+}
+
+// rolledBack function, for completing results of atomic batch that is rolled back,
+// applied results and items that are not reached yet are marked by model.ErrBatchRolledBack
+func rolledBack(results []model.ArticleBatchResult, items []model.ArticleBatchItem) []model.ArticleBatchResult {
+	for i := range results {
+		if results[i].Err == nil {
+			// id of created article is no longer stored
+			results[i].ID = items[i].ID
+			results[i].Applied = false
+			results[i].Article = nil
+			results[i].Err = model.ErrBatchRolledBack
+		}
+	}
+
+	for _, item := range items[len(results):] {
+		results = append(results, model.ArticleBatchResult{Index: item.Index, Action: item.Action, ID: item.ID, Err: model.ErrBatchRolledBack})
+	}
+
+	return results
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/willy182/boilerplate-go-cleanarch/src/shared"
+	"github.com/willy182/boilerplate-go-cleanarch/utils"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// UpdateImage function, for replacing image of article and its resized variants
+func (r *postgresArticleRepo) UpdateImage(ctx context.Context, id int, image string, variants map[string]string) <-chan ResultRepository {
+	ctxRepo := "ArticleRepositoryUpdateImage"
+
+	output := make(chan ResultRepository)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxRepo, "recover_repository_update_image")
+				output <- ResultRepository{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		imgVariants, err := json.Marshal(variants)
+		if err != nil {
+			output <- ResultRepository{Error: err}
+			return
+		}
+
+		row := r.write.Raw(withRevision(`UPDATE `+tableName+` SET image = ?, image_variants = ?, modified = now(), version = version + 1
+			WHERE id = ? AND deleted IS NULL`, articleColumns), image, string(imgVariants), id, shared.CallerFromContext(ctx).Name).Row()
+		article, err := scanArticle(row)
+		if err == sql.ErrNoRows {
+			output <- ResultRepository{Error: shared.ErrDataNotFound}
+			return
+		}
+
+		if err != nil {
+			utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "update_image_article")
+			output <- ResultRepository{Error: err}
+			return
+		}
+		r.related.invalidate()
+
+		output <- ResultRepository{Result: article}
+	}()
+
+	return output
+}
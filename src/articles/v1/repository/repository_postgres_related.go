@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/willy182/boilerplate-go-cleanarch/src/articles/v1/model"
+	"github.com/willy182/boilerplate-go-cleanarch/utils"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// GetRelated function, for find at most limit published articles related to article ordered by their score,
+// score sums shared tags, trigram similarity of title and summary and recency,
+// model.RelatedMaxLimit articles are ranked and cached per article until it is saved or the cache expires
+func (r *postgresArticleRepo) GetRelated(ctx context.Context, articleID, limit int) <-chan ResultRepository {
+	ctxRepo := "ArticleRepositoryGetRelated"
+
+	output := make(chan ResultRepository)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxRepo, "recover_repository_get_related")
+				output <- ResultRepository{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		articles, generation, ok := r.related.get(articleID)
+		if !ok {
+			rows, err := r.read.Raw(`SELECT `+articleColumns+` FROM (
+					SELECT `+tableName+`.*,
+						? * COALESCE(shared_tags.total, 0) +
+						? * similarity(title || ' ' || summary, (SELECT title || ' ' || summary FROM `+tableName+` WHERE id = ?)) +
+						? / (1 + extract(epoch FROM now() - COALESCE(published_at, created)) / 86400 / ?) AS score
+					FROM `+tableName+`
+					LEFT JOIN (
+						SELECT other.article_id, count(*) AS total
+						FROM `+tagTables.joinTable+` source
+						JOIN `+tagTables.joinTable+` other ON other.`+tagTables.column+` = source.`+tagTables.column+`
+						WHERE source.article_id = ? AND other.article_id <> source.article_id
+						GROUP BY other.article_id
+					) shared_tags ON shared_tags.article_id = `+tableName+`.id
+					WHERE `+tableName+`.id <> ? AND status = ? AND deleted IS NULL
+				) ranked
+				ORDER BY score DESC, id DESC
+				LIMIT ?`,
+				model.RelatedTagWeight, model.RelatedTextWeight, articleID, model.RelatedRecencyWeight, model.RelatedRecencyDays,
+				articleID, articleID, model.StatusPublished, model.RelatedMaxLimit).Rows()
+			if err != nil {
+				utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "query_related_articles")
+				output <- ResultRepository{Error: err}
+				return
+			}
+			defer rows.Close()
+
+			articles = []model.Article{}
+			for rows.Next() {
+				article, err := scanArticle(rows)
+				if err != nil {
+					utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "scan_article")
+					output <- ResultRepository{Error: err}
+					return
+				}
+				articles = append(articles, article)
+			}
+
+			if err := rows.Err(); err != nil {
+				output <- ResultRepository{Error: err}
+				return
+			}
+
+			r.related.set(articleID, articles, generation)
+		}
+
+		if len(articles) > limit {
+			articles = articles[:limit]
+		}
+
+		output <- ResultRepository{Result: articles}
+	}()
+
+	return output
+}
@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/willy182/boilerplate-go-cleanarch/src/articles/v1/model"
+	"github.com/willy182/boilerplate-go-cleanarch/src/shared"
+	"github.com/willy182/boilerplate-go-cleanarch/utils"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// GetRevisions function, for find list of revision of an article ordered by newest first
+func (r *postgresArticleRepo) GetRevisions(ctx context.Context, articleID int) <-chan ResultRepository {
+	ctxRepo := "ArticleRepositoryGetRevisions"
+
+	output := make(chan ResultRepository)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxRepo, "recover_repository_get_revisions")
+				output <- ResultRepository{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		rows, err := r.read.Table(revisionTableName).Where("article_id = ?", articleID).
+			Select(revisionColumns).Order("revision DESC").Rows()
+		if err != nil {
+			utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "query_revisions")
+			output <- ResultRepository{Error: err}
+			return
+		}
+		defer rows.Close()
+
+		revisions := []model.ArticleRevision{}
+		for rows.Next() {
+			revision, err := scanRevision(rows)
+			if err != nil {
+				utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "scan_revision")
+				output <- ResultRepository{Error: err}
+				return
+			}
+			revisions = append(revisions, revision)
+		}
+
+		output <- ResultRepository{Result: revisions}
+	}()
+
+	return output
+}
+
+// GetRevision function, for find single revision of an article by its revision number
+func (r *postgresArticleRepo) GetRevision(ctx context.Context, articleID, revision int) <-chan ResultRepository {
+	ctxRepo := "ArticleRepositoryGetRevision"
+
+	output := make(chan ResultRepository)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxRepo, "recover_repository_get_revision")
+				output <- ResultRepository{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		row := r.read.Table(revisionTableName).Where("article_id = ? AND revision = ?", articleID, revision).
+			Select(revisionColumns).Row()
+		result, err := scanRevision(row)
+		if err == sql.ErrNoRows {
+			output <- ResultRepository{Error: shared.ErrDataNotFound}
+			return
+		}
+
+		if err != nil {
+			utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "scan_revision")
+			output <- ResultRepository{Error: err}
+			return
+		}
+
+		output <- ResultRepository{Result: result}
+	}()
+
+	return output
+}
+
+// scanRevision function, for scanning single article revision row
+func scanRevision(row rowScanner) (model.ArticleRevision, error) {
+	var (
+		revision                model.ArticleRevision
+		desc, img, status, slug sql.NullString
+		authorID                sql.NullInt64
+	)
+
+	err := row.Scan(&revision.ArticleID, &revision.Revision, &revision.Author, &revision.Title, &revision.Summary,
+		&desc, &img, &status, &slug, &authorID, &revision.Created)
+	if err != nil {
+		return revision, err
+	}
+
+	revision.Description = desc.String
+	revision.Image = img.String
+	revision.Status = status.String
+	revision.Slug = slug.String
+	revision.AuthorID = int(authorID.Int64)
+
+	return revision, nil
+}
+
+// withRevision function, for wrapping update statement of article so revision of every updated row is written
+// in the same statement, the update has no RETURNING clause, the revision author is the last argument
+// and the updated rows are returned with columns
+func withRevision(update, columns string) string {
+	return `WITH updated AS (` + update + `
+			RETURNING *
+		), revision AS (
+			INSERT INTO ` + revisionTableName + ` (` + revisionColumns + `)
+			SELECT ` + revisionSnapshot + ` FROM updated
+		)
+		SELECT ` + columns + ` FROM updated`
+}
@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/willy182/boilerplate-go-cleanarch/src/articles/v1/model"
+	"github.com/willy182/boilerplate-go-cleanarch/src/shared"
+	"github.com/willy182/boilerplate-go-cleanarch/utils"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// UpdateSchedule function, for set publishing schedule of article when version is still the stored version
+func (r *postgresArticleRepo) UpdateSchedule(ctx context.Context, id int, publishAt, unpublishAt *time.Time, version int) <-chan ResultRepository {
+	ctxRepo := "ArticleRepositoryUpdateSchedule"
+
+	output := make(chan ResultRepository)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxRepo, "recover_repository_update_schedule")
+				output <- ResultRepository{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		row := r.write.Raw(withRevision(`UPDATE `+tableName+` SET publish_at = ?, unpublish_at = ?, modified = now(), version = version + 1
+			WHERE id = ? AND version = ? AND deleted IS NULL`, articleColumns),
+			publishAt, unpublishAt, id, version, shared.CallerFromContext(ctx).Name).Row()
+		article, err := scanArticle(row)
+		if err == sql.ErrNoRows {
+			output <- ResultRepository{Error: shared.ErrDataConflict}
+			return
+		}
+
+		if err != nil {
+			utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "update_schedule_article")
+			output <- ResultRepository{Error: err}
+			return
+		}
+		r.related.invalidate()
+
+		output <- ResultRepository{Result: article}
+	}()
+
+	return output
+}
+
+// ApplySchedule function, for publishing and unpublishing articles whose schedule is due,
+// only articles still in review are published and only published articles are archived,
+// rows locked by another running scheduler are skipped so it is safe to run on several replicas at once,
+// a revision is written for every changed article and cached related articles are dropped
+func (r *postgresArticleRepo) ApplySchedule(ctx context.Context, batchSize int) <-chan ResultRepository {
+	ctxRepo := "ArticleRepositoryApplySchedule"
+
+	output := make(chan ResultRepository)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxRepo, "recover_repository_apply_schedule")
+				output <- ResultRepository{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		published, err := r.flipScheduled(`UPDATE `+tableName+` SET
+				status = 'published',
+				published_at = COALESCE(published_at, publish_at),
+				publish_at = NULL,
+				modified = now(),
+				version = version + 1
+			WHERE id IN (
+				SELECT id FROM `+tableName+`
+				WHERE publish_at <= now() AND status = 'in_review' AND deleted IS NULL
+				ORDER BY publish_at
+				LIMIT ?
+				FOR UPDATE SKIP LOCKED
+			)`, batchSize)
+		if err != nil {
+			utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "publish_scheduled_articles")
+			output <- ResultRepository{Error: err}
+			return
+		}
+
+		unpublished, err := r.flipScheduled(`UPDATE `+tableName+` SET
+				status = 'archived',
+				unpublish_at = NULL,
+				modified = now(),
+				version = version + 1
+			WHERE id IN (
+				SELECT id FROM `+tableName+`
+				WHERE unpublish_at <= now() AND status = 'published' AND deleted IS NULL
+				ORDER BY unpublish_at
+				LIMIT ?
+				FOR UPDATE SKIP LOCKED
+			)`, batchSize)
+		if err != nil {
+			utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "unpublish_scheduled_articles")
+			output <- ResultRepository{Error: err}
+			return
+		}
+
+		if len(published) > 0 || len(unpublished) > 0 {
+			r.related.invalidate()
+		}
+
+		result := model.ScheduleResult{Published: len(published), Unpublished: len(unpublished)}
+		output <- ResultRepository{Result: result}
+	}()
+
+	return output
+}
+
+// flipScheduled function, for running status update of scheduled articles and returning the changed ids,
+// the update and the revision of every changed article are written in one statement
+func (r *postgresArticleRepo) flipScheduled(update string, batchSize int) ([]int, error) {
+	rows, err := r.write.Raw(withRevision(update, "id"), batchSize, model.ScheduleAuthor).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
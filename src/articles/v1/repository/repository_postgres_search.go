@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/willy182/boilerplate-go-cleanarch/src/articles/v1/model"
+	"github.com/willy182/boilerplate-go-cleanarch/utils"
+
+	"github.com/jinzhu/gorm"
+	log "github.com/sirupsen/logrus"
+)
+
+// searchColumns mapping of text search configuration into its generated tsvector column
+var searchColumns = map[string]string{
+	model.SearchLanguageSimple:  "search_simple",
+	model.SearchLanguageEnglish: "search_english",
+}
+
+// headlineSource text of article given to ts_headline, it is HTML escaped first
+// so only the <mark> of headlineOptions is markup in the highlighted snippet
+const headlineSource = `replace(replace(replace(replace(summary || ' ' || coalesce(description, ''),
+	'&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '"', '&quot;')`
+
+// headlineOptions options of ts_headline for highlighting matched words
+const headlineOptions = "StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=30, MinWords=10"
+
+// Search function, for find list of article matching params query using full text search ordered by its rank
+func (r *postgresArticleRepo) Search(ctx context.Context, params model.ArticleParams) <-chan ResultRepository {
+	ctxRepo := "ArticleRepositorySearch"
+
+	output := make(chan ResultRepository)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxRepo, "recover_repository_search")
+				output <- ResultRepository{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		column := searchColumns[params.Language]
+		db := r.search(params).
+			Select(articleColumns+", ts_rank("+column+", query) AS rank, "+
+				"ts_headline(CAST(? AS regconfig), "+headlineSource+", query, ?) AS headline",
+				params.Language, headlineOptions).
+			Order("rank DESC").Order("id DESC")
+
+		if params.Limit > 0 {
+			db = db.Offset((params.Page - 1) * params.Limit).Limit(params.Limit)
+		}
+
+		rows, err := db.Rows()
+		if err != nil {
+			utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "query_search_articles")
+			output <- ResultRepository{Error: err}
+			return
+		}
+		defer rows.Close()
+
+		results := []model.ArticleSearchResult{}
+		for rows.Next() {
+			var result model.ArticleSearchResult
+			result.Article, err = scanArticle(rows, &result.Rank, &result.Headline)
+			if err != nil {
+				utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "scan_search_article")
+				output <- ResultRepository{Error: err}
+				return
+			}
+			results = append(results, result)
+		}
+
+		output <- ResultRepository{Result: results}
+	}()
+
+	return output
+}
+
+// SearchTotal function, for count total article matching params query using full text search
+func (r *postgresArticleRepo) SearchTotal(ctx context.Context, params model.ArticleParams) <-chan ResultRepository {
+	ctxRepo := "ArticleRepositorySearchTotal"
+
+	output := make(chan ResultRepository)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxRepo, "recover_repository_search_total")
+				output <- ResultRepository{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		var total int
+		if err := r.search(params).Count(&total).Error; err != nil {
+			utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "count_search_articles")
+			output <- ResultRepository{Error: err}
+			return
+		}
+
+		output <- ResultRepository{Result: total}
+	}()
+
+	return output
+}
+
+// search function, for building full text search query of params query,
+// the parsed query is joined as "query" so it can be used for ranking and highlighting
+func (r *postgresArticleRepo) search(params model.ArticleParams) *gorm.DB {
+	// params query is matched using tsvector instead of the ILIKE filter
+	filterParams := params
+	filterParams.Query = ""
+
+	return r.filter(r.read.Table(tableName), filterParams).
+		Joins("CROSS JOIN websearch_to_tsquery(CAST(? AS regconfig), ?) AS query", params.Language, params.Query).
+		Where(searchColumns[params.Language] + " @@ query")
+}
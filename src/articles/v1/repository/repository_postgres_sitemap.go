@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/willy182/boilerplate-go-cleanarch/src/articles/v1/model"
+	"github.com/willy182/boilerplate-go-cleanarch/utils"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// GetSitemapIndex function, for splitting published articles ordered by id into sitemap pages of pageSize articles,
+// every page starts after the last id of its previous page
+func (r *postgresArticleRepo) GetSitemapIndex(ctx context.Context, pageSize int) <-chan ResultRepository {
+	ctxRepo := "ArticleRepositoryGetSitemapIndex"
+
+	output := make(chan ResultRepository)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxRepo, "recover_repository_get_sitemap_index")
+				output <- ResultRepository{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		rows, err := r.read.Raw(`SELECT min(after), count(*), max(modified) FROM (
+				SELECT (row_number() OVER (ORDER BY id) - 1) / ? AS page, COALESCE(lag(id) OVER (ORDER BY id), 0) AS after,
+					COALESCE(modified, created) AS modified
+				FROM `+tableName+` WHERE status = ? AND deleted IS NULL
+			) pages GROUP BY page ORDER BY page`, pageSize, model.StatusPublished).Rows()
+		if err != nil {
+			utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "get_sitemap_index")
+			output <- ResultRepository{Error: err}
+			return
+		}
+		defer rows.Close()
+
+		pages := []model.SitemapPage{}
+		for rows.Next() {
+			var page model.SitemapPage
+			if err := rows.Scan(&page.After, &page.Total, &page.Modified); err != nil {
+				utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "scan_sitemap_page")
+				output <- ResultRepository{Error: err}
+				return
+			}
+			pages = append(pages, page)
+		}
+
+		if err := rows.Err(); err != nil {
+			output <- ResultRepository{Error: err}
+			return
+		}
+
+		output <- ResultRepository{Result: pages}
+	}()
+
+	return output
+}
+
+// GetSitemapEntries function, for find pageSize published articles ordered by id whose id is greater than after
+func (r *postgresArticleRepo) GetSitemapEntries(ctx context.Context, after, pageSize int) <-chan ResultRepository {
+	ctxRepo := "ArticleRepositoryGetSitemapEntries"
+
+	output := make(chan ResultRepository)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxRepo, "recover_repository_get_sitemap_entries")
+				output <- ResultRepository{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		rows, err := r.read.Raw(`SELECT id, slug, COALESCE(modified, created) FROM `+tableName+`
+			WHERE status = ? AND deleted IS NULL AND id > ?
+			ORDER BY id LIMIT ?`, model.StatusPublished, after, pageSize).Rows()
+		if err != nil {
+			utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "get_sitemap_entries")
+			output <- ResultRepository{Error: err}
+			return
+		}
+		defer rows.Close()
+
+		entries := []model.SitemapEntry{}
+		for rows.Next() {
+			var entry model.SitemapEntry
+			if err := rows.Scan(&entry.ID, &entry.Slug, &entry.Modified); err != nil {
+				utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "scan_sitemap_entry")
+				output <- ResultRepository{Error: err}
+				return
+			}
+			entries = append(entries, entry)
+		}
+
+		if err := rows.Err(); err != nil {
+			output <- ResultRepository{Error: err}
+			return
+		}
+
+		output <- ResultRepository{Result: entries}
+	}()
+
+	return output
+}
@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/willy182/boilerplate-go-cleanarch/src/shared"
+	"github.com/willy182/boilerplate-go-cleanarch/utils"
+
+	"github.com/jinzhu/gorm"
+	"github.com/lib/pq"
+	log "github.com/sirupsen/logrus"
+)
+
+// pqUniqueViolation postgres error code of unique constraint violation
+const pqUniqueViolation = "23505"
+
+// GetBySlug function, for find article by its current slug or by one of its old slug,
+// current slug is preferred when the slug matches both, fields is applied the same as GetByID
+func (r *postgresArticleRepo) GetBySlug(ctx context.Context, slug string, fields ...string) <-chan ResultRepository {
+	ctxRepo := "ArticleRepositoryGetBySlug"
+
+	output := make(chan ResultRepository)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxRepo, "recover_repository_get_by_slug")
+				output <- ResultRepository{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		// slug is needed for redirecting old slug into the current one
+		if len(fields) > 0 {
+			fields = append([]string{"slug"}, fields...)
+		}
+
+		columns := selectColumns(fields)
+		row := r.read.Table(tableName).
+			Where("deleted IS NULL AND (slug = ? OR id = (SELECT article_id FROM "+slugHistoryTableName+" WHERE slug = ?))", slug, slug).
+			Select(strings.Join(columns, ", ")).
+			Order(gorm.Expr("slug = ? DESC", slug)).
+			Limit(1).Row()
+		article, err := scanArticleColumns(row, columns)
+		if err == sql.ErrNoRows {
+			output <- ResultRepository{Error: shared.ErrDataNotFound}
+			return
+		}
+
+		if err != nil {
+			utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "scan_article")
+			output <- ResultRepository{Error: err}
+			return
+		}
+
+		output <- ResultRepository{Result: article}
+	}()
+
+	return output
+}
+
+// GetAvailableSlug function, for find the first slug from base, base-2, base-3 and so on
+// that is not used as current or old slug by article other than articleID
+func (r *postgresArticleRepo) GetAvailableSlug(ctx context.Context, base string, articleID int) <-chan ResultRepository {
+	ctxRepo := "ArticleRepositoryGetAvailableSlug"
+
+	output := make(chan ResultRepository)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxRepo, "recover_repository_get_available_slug")
+				output <- ResultRepository{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		pattern := likeEscaper.Replace(base) + "-%"
+		rows, err := r.read.Raw(`SELECT slug FROM `+tableName+` WHERE (slug = ? OR slug LIKE ?) AND id <> ?
+			UNION SELECT slug FROM `+slugHistoryTableName+` WHERE (slug = ? OR slug LIKE ?) AND article_id <> ?`,
+			base, pattern, articleID, base, pattern, articleID).Rows()
+		if err != nil {
+			utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "query_article_slugs")
+			output <- ResultRepository{Error: err}
+			return
+		}
+		defer rows.Close()
+
+		used := make(map[string]bool)
+		for rows.Next() {
+			var slug string
+			if err := rows.Scan(&slug); err != nil {
+				utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "scan_article_slug")
+				output <- ResultRepository{Error: err}
+				return
+			}
+			used[slug] = true
+		}
+
+		slug := base
+		for i := 2; used[slug]; i++ {
+			suffix := "-" + strconv.Itoa(i)
+			slug = strings.TrimRight(truncate(base, shared.SlugMaxLength-len(suffix)), "-") + suffix
+		}
+
+		output <- ResultRepository{Result: slug}
+	}()
+
+	return output
+}
+
+// saveSlugHistory function, for keeping old slug of article to be redirected into its new slug,
+// new slug that was an old slug of the same article is no longer kept as history
+func (r *postgresArticleRepo) saveSlugHistory(tx *gorm.DB, articleID int, oldSlug, newSlug string) error {
+	if oldSlug == "" || oldSlug == newSlug {
+		return nil
+	}
+
+	err := tx.Exec(`INSERT INTO `+slugHistoryTableName+` (slug, article_id, created) VALUES (?, ?, now())
+		ON CONFLICT (slug) DO UPDATE SET article_id = EXCLUDED.article_id, created = EXCLUDED.created`,
+		oldSlug, articleID).Error
+	if err != nil {
+		return err
+	}
+
+	return tx.Exec(`DELETE FROM `+slugHistoryTableName+` WHERE slug = ? AND article_id = ?`, newSlug, articleID).Error
+}
+
+// isUniqueViolation function, for checking whether err is caused by unique constraint violation
+func isUniqueViolation(err error) bool {
+	pqErr, ok := err.(*pq.Error)
+	return ok && pqErr.Code == pqUniqueViolation
+}
+
+// truncate function, for cutting str into at most n bytes
+func truncate(str string, n int) string {
+	if len(str) > n {
+		return str[:n]
+	}
+	return str
+}
@@ -0,0 +1,323 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/willy182/boilerplate-go-cleanarch/src/articles/v1/model"
+	"github.com/willy182/boilerplate-go-cleanarch/src/shared"
+	"github.com/willy182/boilerplate-go-cleanarch/utils"
+
+	"github.com/jinzhu/gorm"
+	log "github.com/sirupsen/logrus"
+)
+
+// taxonomyTables data of struct for table names of a taxonomy and its relation into article
+type taxonomyTables struct {
+	table     string
+	joinTable string
+	column    string
+}
+
+var (
+	tagTables      = taxonomyTables{table: "tags", joinTable: "article_tags", column: "tag_id"}
+	categoryTables = taxonomyTables{table: "categories", joinTable: "article_categories", column: "category_id"}
+)
+
+// AttachTags function, for attaching tags into article, unknown tag is created by its slug
+func (r *postgresArticleRepo) AttachTags(ctx context.Context, articleID int, tags []model.Tag) <-chan error {
+	return r.attachTaxonomy(ctx, "ArticleRepositoryAttachTags", tagTables, articleID, tags)
+}
+
+// DetachTag function, for detaching tag from article by the tag slug
+func (r *postgresArticleRepo) DetachTag(ctx context.Context, articleID int, slug string) <-chan error {
+	return r.detachTaxonomy(ctx, "ArticleRepositoryDetachTag", tagTables, articleID, slug)
+}
+
+// GetTagsByArticleIDs function, for find tags of each article, result is mapped by article id
+func (r *postgresArticleRepo) GetTagsByArticleIDs(ctx context.Context, articleIDs []int) <-chan ResultRepository {
+	return r.getTaxonomiesByArticleIDs("ArticleRepositoryGetTagsByArticleIDs", tagTables, articleIDs)
+}
+
+// GetTagCounts function, for find tags with total articles having the tag,
+// only non deleted articles in one of statuses are counted, empty statuses means any status
+func (r *postgresArticleRepo) GetTagCounts(ctx context.Context, statuses []string) <-chan ResultRepository {
+	return r.getTaxonomyCounts("ArticleRepositoryGetTagCounts", tagTables, statuses)
+}
+
+// AttachCategories function, for attaching categories into article, unknown category is created by its slug
+func (r *postgresArticleRepo) AttachCategories(ctx context.Context, articleID int, categories []model.Category) <-chan error {
+	return r.attachTaxonomy(ctx, "ArticleRepositoryAttachCategories", categoryTables, articleID, categories)
+}
+
+// DetachCategory function, for detaching category from article by the category slug
+func (r *postgresArticleRepo) DetachCategory(ctx context.Context, articleID int, slug string) <-chan error {
+	return r.detachTaxonomy(ctx, "ArticleRepositoryDetachCategory", categoryTables, articleID, slug)
+}
+
+// GetCategoriesByArticleIDs function, for find categories of each article, result is mapped by article id
+func (r *postgresArticleRepo) GetCategoriesByArticleIDs(ctx context.Context, articleIDs []int) <-chan ResultRepository {
+	return r.getTaxonomiesByArticleIDs("ArticleRepositoryGetCategoriesByArticleIDs", categoryTables, articleIDs)
+}
+
+// GetCategoryCounts function, for find categories with total articles having the category,
+// only non deleted articles in one of statuses are counted, empty statuses means any status
+func (r *postgresArticleRepo) GetCategoryCounts(ctx context.Context, statuses []string) <-chan ResultRepository {
+	return r.getTaxonomyCounts("ArticleRepositoryGetCategoryCounts", categoryTables, statuses)
+}
+
+// attachTaxonomy function, for attaching terms of taxonomy into article in single transaction,
+// article version is increased because its representation is changed
+func (r *postgresArticleRepo) attachTaxonomy(ctx context.Context, ctxRepo string, tables taxonomyTables, articleID int, terms []model.Tag) <-chan error {
+	output := make(chan error)
+
+	go func() {
+		// begin
+		tx := r.write.Begin()
+
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxRepo, "recover_repository_attach_"+tables.table)
+				tx.Rollback()
+				output <- fmt.Errorf(message)
+			}
+			close(output)
+		}()
+
+		if err := tx.Error; err != nil {
+			utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "tx_error")
+			output <- err
+			return
+		}
+
+		if err := r.attachTerms(ctx, tx, ctxRepo, tables, articleID, terms); err != nil {
+			tx.Rollback()
+			output <- err
+			return
+		}
+
+		if err := tx.Commit().Error; err != nil {
+			utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "tx_commit")
+			output <- err
+			return
+		}
+		r.related.invalidate()
+
+		output <- nil
+	}()
+
+	return output
+}
+
+// attachTerms function, for attaching terms of taxonomy into article inside transaction tx,
+// the caller owns tx and must roll it back on error
+func (r *postgresArticleRepo) attachTerms(ctx context.Context, tx *gorm.DB, ctxRepo string, tables taxonomyTables, articleID int, terms []model.Tag) error {
+	if err := r.touchArticle(ctx, tx, ctxRepo, articleID); err != nil {
+		return err
+	}
+
+	for _, term := range terms {
+		// no-op update on conflict lets the existing id be returned
+		var termID int
+		err := tx.Raw(`INSERT INTO `+tables.table+` (name, slug, created) VALUES (?, ?, now())
+			ON CONFLICT (slug) DO UPDATE SET slug = EXCLUDED.slug
+			RETURNING id`, term.Name, term.Slug).Row().Scan(&termID)
+		if err != nil {
+			utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "save_"+tables.table)
+			return err
+		}
+
+		err = tx.Exec(`INSERT INTO `+tables.joinTable+` (article_id, `+tables.column+`, created) VALUES (?, ?, now())
+			ON CONFLICT DO NOTHING`, articleID, termID).Error
+		if err != nil {
+			utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "save_"+tables.joinTable)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// touchArticle function, for increasing version of article inside transaction tx because its terms are changed,
+// the new version is written as revision
+func (r *postgresArticleRepo) touchArticle(ctx context.Context, tx *gorm.DB, ctxRepo string, articleID int) error {
+	var id int
+	err := tx.Raw(withRevision(`UPDATE `+tableName+` SET modified = now(), version = version + 1
+		WHERE id = ? AND deleted IS NULL`, "id"), articleID, shared.CallerFromContext(ctx).Name).Row().Scan(&id)
+	if err == sql.ErrNoRows {
+		return shared.ErrDataNotFound
+	}
+
+	if err != nil {
+		utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "touch_article")
+		return err
+	}
+
+	return nil
+}
+
+// detachTaxonomy function, for detaching term of taxonomy from article in single transaction,
+// article version is increased because its representation is changed
+func (r *postgresArticleRepo) detachTaxonomy(ctx context.Context, ctxRepo string, tables taxonomyTables, articleID int, slug string) <-chan error {
+	output := make(chan error)
+
+	go func() {
+		// begin
+		tx := r.write.Begin()
+
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxRepo, "recover_repository_detach_"+tables.table)
+				tx.Rollback()
+				output <- fmt.Errorf(message)
+			}
+			close(output)
+		}()
+
+		if err := tx.Error; err != nil {
+			utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "tx_error")
+			output <- err
+			return
+		}
+
+		if err := r.touchArticle(ctx, tx, ctxRepo, articleID); err != nil {
+			tx.Rollback()
+			output <- err
+			return
+		}
+
+		db := tx.Exec(`DELETE FROM `+tables.joinTable+` USING `+tables.table+`
+			WHERE `+tables.joinTable+`.`+tables.column+` = `+tables.table+`.id
+			AND `+tables.joinTable+`.article_id = ? AND `+tables.table+`.slug = ?`, articleID, slug)
+		if db.Error != nil {
+			utils.Log(log.ErrorLevel, db.Error.Error(), ctxRepo, "delete_"+tables.joinTable)
+			tx.Rollback()
+			output <- db.Error
+			return
+		}
+
+		if db.RowsAffected == 0 {
+			tx.Rollback()
+			output <- shared.ErrDataNotFound
+			return
+		}
+
+		if err := tx.Commit().Error; err != nil {
+			utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "tx_commit")
+			output <- err
+			return
+		}
+		r.related.invalidate()
+
+		output <- nil
+	}()
+
+	return output
+}
+
+// getTaxonomiesByArticleIDs function, for find terms of taxonomy of each article ordered by its name
+func (r *postgresArticleRepo) getTaxonomiesByArticleIDs(ctxRepo string, tables taxonomyTables, articleIDs []int) <-chan ResultRepository {
+	output := make(chan ResultRepository)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxRepo, "recover_repository_get_"+tables.table)
+				output <- ResultRepository{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		result := make(map[int][]model.Tag)
+		if len(articleIDs) == 0 {
+			output <- ResultRepository{Result: result}
+			return
+		}
+
+		rows, err := r.read.Table(tables.joinTable).
+			Joins("JOIN "+tables.table+" ON "+tables.table+".id = "+tables.joinTable+"."+tables.column).
+			Where(tables.joinTable+".article_id IN (?)", articleIDs).
+			Select(tables.joinTable + ".article_id, " + tables.table + ".id, " + tables.table + ".name, " + tables.table + ".slug").
+			Order(tables.table + ".name").
+			Rows()
+		if err != nil {
+			utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "query_"+tables.table)
+			output <- ResultRepository{Error: err}
+			return
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var (
+				articleID int
+				term      model.Tag
+			)
+
+			if err := rows.Scan(&articleID, &term.ID, &term.Name, &term.Slug); err != nil {
+				utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "scan_"+tables.table)
+				output <- ResultRepository{Error: err}
+				return
+			}
+			result[articleID] = append(result[articleID], term)
+		}
+
+		output <- ResultRepository{Result: result}
+	}()
+
+	return output
+}
+
+// getTaxonomyCounts function, for find terms of taxonomy with total articles ordered by the most used
+func (r *postgresArticleRepo) getTaxonomyCounts(ctxRepo string, tables taxonomyTables, statuses []string) <-chan ResultRepository {
+	output := make(chan ResultRepository)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxRepo, "recover_repository_get_"+tables.table+"_counts")
+				output <- ResultRepository{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		db := r.read.Table(tables.table).
+			Joins("JOIN " + tables.joinTable + " ON " + tables.joinTable + "." + tables.column + " = " + tables.table + ".id").
+			Joins("JOIN " + tableName + " ON " + tableName + ".id = " + tables.joinTable + ".article_id AND " + tableName + ".deleted IS NULL")
+
+		if len(statuses) > 0 {
+			db = db.Where(tableName+".status IN (?)", statuses)
+		}
+
+		rows, err := db.
+			Select(tables.table + ".id, " + tables.table + ".name, " + tables.table + ".slug, COUNT(*) AS articles").
+			Group(tables.table + ".id").
+			Order("articles DESC").Order(tables.table + ".name").
+			Rows()
+		if err != nil {
+			utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "query_"+tables.table+"_counts")
+			output <- ResultRepository{Error: err}
+			return
+		}
+		defer rows.Close()
+
+		counts := []model.TagCount{}
+		for rows.Next() {
+			var count model.TagCount
+			if err := rows.Scan(&count.ID, &count.Name, &count.Slug, &count.Articles); err != nil {
+				utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "scan_"+tables.table+"_counts")
+				output <- ResultRepository{Error: err}
+				return
+			}
+			counts = append(counts, count)
+		}
+
+		output <- ResultRepository{Result: counts}
+	}()
+
+	return output
+}
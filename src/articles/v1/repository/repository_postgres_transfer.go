@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/willy182/boilerplate-go-cleanarch/src/articles/v1/model"
+	"github.com/willy182/boilerplate-go-cleanarch/utils"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Export function, for streaming filtered articles ordered by id one article per result,
+// rows are read from the cursor as they are consumed so the table is never loaded into memory at once,
+// streaming is stopped when ctx is done
+func (r *postgresArticleRepo) Export(ctx context.Context, params model.ArticleParams) <-chan ResultRepository {
+	ctxRepo := "ArticleRepositoryExport"
+
+	output := make(chan ResultRepository, model.ExportBatchSize)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxRepo, "recover_repository_export")
+				output <- ResultRepository{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		columns := selectColumns(nil)
+		rows, err := r.filter(r.read.Table(tableName), params).Select(strings.Join(columns, ", ")).Order("id").Rows()
+		if err != nil {
+			utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "query_articles")
+			output <- ResultRepository{Error: err}
+			return
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			article, err := scanArticleColumns(rows, columns)
+			if err != nil {
+				utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "scan_article")
+				output <- ResultRepository{Error: err}
+				return
+			}
+
+			select {
+			case output <- ResultRepository{Result: article}:
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		if err := rows.Err(); err != nil {
+			utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "iterate_articles")
+			output <- ResultRepository{Error: err}
+		}
+	}()
+
+	return output
+}
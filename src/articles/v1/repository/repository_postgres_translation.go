@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/willy182/boilerplate-go-cleanarch/src/articles/v1/model"
+	"github.com/willy182/boilerplate-go-cleanarch/src/shared"
+	"github.com/willy182/boilerplate-go-cleanarch/utils"
+
+	"github.com/lib/pq"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	translationTableName = "article_translations"
+	translationColumns   = "article_id, locale, title, summary, description, description_html, created, modified"
+)
+
+// SaveTranslation function, for creating or replacing translation of article in its locale
+func (r *postgresArticleRepo) SaveTranslation(ctx context.Context, param *model.GormArticleTranslation) <-chan ResultRepository {
+	ctxRepo := "ArticleRepositorySaveTranslation"
+
+	output := make(chan ResultRepository)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxRepo, "recover_repository_save_translation")
+				output <- ResultRepository{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		// rendered html is derived from the markdown description the same way as article
+		param.DescriptionHTML = shared.RenderMarkdown(param.Description)
+
+		row := r.write.Raw(`INSERT INTO `+translationTableName+` (article_id, locale, title, summary, description, description_html, created)
+			VALUES (?, ?, ?, ?, ?, ?, COALESCE(?, now()))
+			ON CONFLICT (article_id, locale) DO UPDATE SET
+				title = EXCLUDED.title,
+				summary = EXCLUDED.summary,
+				description = EXCLUDED.description,
+				description_html = EXCLUDED.description_html,
+				modified = now()
+			RETURNING `+translationColumns,
+			param.ArticleID, param.Locale, param.Title, param.Summary, param.Description, param.DescriptionHTML, param.Created).Row()
+
+		translation, err := scanTranslation(row)
+		if err != nil {
+			utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "save_translation")
+			output <- ResultRepository{Error: err}
+			return
+		}
+
+		output <- ResultRepository{Result: translation}
+	}()
+
+	return output
+}
+
+// GetTranslations function, for find every translation of article ordered by locale
+func (r *postgresArticleRepo) GetTranslations(ctx context.Context, articleID int) <-chan ResultRepository {
+	ctxRepo := "ArticleRepositoryGetTranslations"
+
+	output := make(chan ResultRepository)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxRepo, "recover_repository_get_translations")
+				output <- ResultRepository{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		rows, err := r.read.Raw(`SELECT `+translationColumns+` FROM `+translationTableName+`
+			WHERE article_id = ? ORDER BY locale`, articleID).Rows()
+		if err != nil {
+			utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "query_translations")
+			output <- ResultRepository{Error: err}
+			return
+		}
+		defer rows.Close()
+
+		translations := []model.ArticleTranslation{}
+		for rows.Next() {
+			translation, err := scanTranslation(rows)
+			if err != nil {
+				utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "scan_translation")
+				output <- ResultRepository{Error: err}
+				return
+			}
+			translations = append(translations, translation)
+		}
+
+		output <- ResultRepository{Result: translations}
+	}()
+
+	return output
+}
+
+// GetTranslationsByArticleIDs function, for find translations of each article in one of locales, result is mapped by article id
+func (r *postgresArticleRepo) GetTranslationsByArticleIDs(ctx context.Context, articleIDs []int, locales []string) <-chan ResultRepository {
+	ctxRepo := "ArticleRepositoryGetTranslationsByArticleIDs"
+
+	output := make(chan ResultRepository)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxRepo, "recover_repository_get_translations_by_article_ids")
+				output <- ResultRepository{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		result := make(map[int][]model.ArticleTranslation)
+		if len(articleIDs) == 0 || len(locales) == 0 {
+			output <- ResultRepository{Result: result}
+			return
+		}
+
+		rows, err := r.read.Raw(`SELECT `+translationColumns+` FROM `+translationTableName+`
+			WHERE article_id IN (?) AND locale IN (?)`, articleIDs, locales).Rows()
+		if err != nil {
+			utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "query_translations")
+			output <- ResultRepository{Error: err}
+			return
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			translation, err := scanTranslation(rows)
+			if err != nil {
+				utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "scan_translation")
+				output <- ResultRepository{Error: err}
+				return
+			}
+			result[translation.ArticleID] = append(result[translation.ArticleID], translation)
+		}
+
+		output <- ResultRepository{Result: result}
+	}()
+
+	return output
+}
+
+// scanTranslation function, for scanning single translation row of translationColumns
+func scanTranslation(row rowScanner) (model.ArticleTranslation, error) {
+	var (
+		translation    model.ArticleTranslation
+		desc, descHTML sql.NullString
+		modified       pq.NullTime
+	)
+
+	err := row.Scan(&translation.ArticleID, &translation.Locale, &translation.Title, &translation.Summary,
+		&desc, &descHTML, &translation.Created, &modified)
+	if err != nil {
+		return translation, err
+	}
+
+	translation.Description = desc.String
+	translation.DescriptionHTML = descHTML.String
+	if modified.Valid {
+		translation.Modified = modified.Time.Format(time.RFC3339)
+	}
+
+	return translation, nil
+}
@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/willy182/boilerplate-go-cleanarch/src/articles/v1/model"
+	"github.com/willy182/boilerplate-go-cleanarch/utils"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const viewTableName = "article_daily_views"
+
+// IncrementViews function, for adding views into daily counters of articles in single statement
+func (r *postgresArticleRepo) IncrementViews(ctx context.Context, views []model.ArticleView) <-chan error {
+	ctxRepo := "ArticleRepositoryIncrementViews"
+
+	output := make(chan error)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxRepo, "recover_repository_increment_views")
+				output <- fmt.Errorf(message)
+			}
+			close(output)
+		}()
+
+		if len(views) == 0 {
+			output <- nil
+			return
+		}
+
+		values := make([]string, 0, len(views))
+		args := make([]interface{}, 0, len(views)*3)
+		for _, view := range views {
+			values = append(values, "(?, CAST(? AS date), ?)")
+			args = append(args, view.ArticleID, view.Day, view.Views)
+		}
+
+		err := r.write.Exec(`INSERT INTO `+viewTableName+` (article_id, day, views)
+			VALUES `+strings.Join(values, ", ")+`
+			ON CONFLICT (article_id, day) DO UPDATE SET views = `+viewTableName+`.views + EXCLUDED.views`, args...).Error
+		if err != nil {
+			utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "increment_views")
+		}
+
+		output <- err
+	}()
+
+	return output
+}
+
+// GetPopular function, for find at most limit published articles with the most views since day
+func (r *postgresArticleRepo) GetPopular(ctx context.Context, since string, limit int) <-chan ResultRepository {
+	ctxRepo := "ArticleRepositoryGetPopular"
+
+	output := make(chan ResultRepository)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxRepo, "recover_repository_get_popular")
+				output <- ResultRepository{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		rows, err := r.read.Raw(`SELECT `+articleColumns+`, total FROM `+tableName+`
+			JOIN (
+				SELECT article_id, sum(views) AS total FROM `+viewTableName+`
+				WHERE day >= CAST(? AS date)
+				GROUP BY article_id
+			) popular ON popular.article_id = `+tableName+`.id
+			WHERE status = ? AND deleted IS NULL
+			ORDER BY total DESC, id DESC
+			LIMIT ?`, since, model.StatusPublished, limit).Rows()
+		if err != nil {
+			utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "query_popular_articles")
+			output <- ResultRepository{Error: err}
+			return
+		}
+		defer rows.Close()
+
+		articles := []model.PopularArticle{}
+		for rows.Next() {
+			var popular model.PopularArticle
+			popular.Article, err = scanArticle(rows, &popular.Views)
+			if err != nil {
+				utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "scan_article")
+				output <- ResultRepository{Error: err}
+				return
+			}
+			articles = append(articles, popular)
+		}
+
+		if err := rows.Err(); err != nil {
+			output <- ResultRepository{Error: err}
+			return
+		}
+
+		output <- ResultRepository{Result: articles}
+	}()
+
+	return output
+}
@@ -14,7 +14,43 @@ type ResultUseCase struct {
 
 // UseCase use case for category
 type UseCase interface {
-	Save(ctx context.Context, param *model.GormArticle) <-chan error
-	GetByID(ctx context.Context, ID int) <-chan ResultUseCase
-	// GetAll(ctx context.Context, params model.CategoryParams, req *http.Request) <-chan ResultUseCase
+	Save(ctx context.Context, param *model.GormArticle) <-chan ResultUseCase
+	GetByID(ctx context.Context, ID int, fields ...string) <-chan ResultUseCase
+	GetBySlug(ctx context.Context, slug string, fields ...string) <-chan ResultUseCase
+	Create(ctx context.Context, param model.ArticleRequest) <-chan ResultUseCase
+	Update(ctx context.Context, ID int, param model.ArticleRequest) <-chan ResultUseCase
+	Patch(ctx context.Context, ID int, param model.ArticlePatchRequest) <-chan ResultUseCase
+	Delete(ctx context.Context, ID int) <-chan error
+	Restore(ctx context.Context, ID int) <-chan ResultUseCase
+	ChangeStatus(ctx context.Context, ID int, status string, version int) <-chan ResultUseCase
+	Batch(ctx context.Context, param model.ArticleBatchRequest) <-chan ResultUseCase
+	Schedule(ctx context.Context, ID int, param model.ArticleScheduleRequest) <-chan ResultUseCase
+	ApplySchedule(ctx context.Context, batchSize int) <-chan ResultUseCase
+	AttachTags(ctx context.Context, ID int, param model.TaxonomyRequest) <-chan ResultUseCase
+	DetachTag(ctx context.Context, ID int, slug string) <-chan ResultUseCase
+	AttachCategories(ctx context.Context, ID int, param model.TaxonomyRequest) <-chan ResultUseCase
+	DetachCategory(ctx context.Context, ID int, slug string) <-chan ResultUseCase
+	UploadImage(ctx context.Context, ID int, param model.ArticleImageUpload) <-chan ResultUseCase
+	GetTags(ctx context.Context) <-chan ResultUseCase
+	GetCategories(ctx context.Context) <-chan ResultUseCase
+	SaveTranslation(ctx context.Context, ID int, param model.ArticleTranslationRequest) <-chan ResultUseCase
+	GetTranslations(ctx context.Context, ID int) <-chan ResultUseCase
+	Translate(ctx context.Context, articles []model.Article, locales []string) <-chan ResultUseCase
+	GetRevisions(ctx context.Context, ID int) <-chan ResultUseCase
+	GetRevision(ctx context.Context, ID, revision int) <-chan ResultUseCase
+	DiffRevisions(ctx context.Context, ID, from, to int) <-chan ResultUseCase
+	RestoreRevision(ctx context.Context, ID, revision, version int) <-chan ResultUseCase
+	GetAll(ctx context.Context, params model.ArticleParams) <-chan ResultUseCase
+	GetAllByCursor(ctx context.Context, params model.ArticleParams) <-chan ResultUseCase
+	GetAllByAuthor(ctx context.Context, authorID int, params model.ArticleParams) <-chan ResultUseCase
+	GetFeed(ctx context.Context, params model.ArticleFeedParams) <-chan ResultUseCase
+	GetSitemap(ctx context.Context, after int) <-chan ResultUseCase
+	Include(ctx context.Context, articles []model.Article, include []string) <-chan ResultUseCase
+	Search(ctx context.Context, params model.ArticleParams) <-chan ResultUseCase
+	Import(ctx context.Context, rows <-chan model.ArticleImportRow, dryRun bool) <-chan ResultUseCase
+	Export(ctx context.Context, params model.ArticleParams) <-chan ResultUseCase
+	GetRelated(ctx context.Context, ID, limit int) <-chan ResultUseCase
+	RecordView(ctx context.Context, article model.Article)
+	FlushViews(ctx context.Context) <-chan ResultUseCase
+	GetPopular(ctx context.Context, params model.PopularParams) <-chan ResultUseCase
 }
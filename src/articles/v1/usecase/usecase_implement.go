@@ -2,10 +2,15 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
+	"github.com/willy182/boilerplate-go-cleanarch/config/storage"
 	"github.com/willy182/boilerplate-go-cleanarch/src/articles/v1/model"
 	"github.com/willy182/boilerplate-go-cleanarch/src/articles/v1/repository"
+	authorRepository "github.com/willy182/boilerplate-go-cleanarch/src/authors/v1/repository"
+	"github.com/willy182/boilerplate-go-cleanarch/src/shared"
 	"github.com/willy182/boilerplate-go-cleanarch/utils"
 
 	log "github.com/sirupsen/logrus"
@@ -13,46 +18,52 @@ import (
 
 type articleUseCase struct {
 	articleRepo repository.Repository
+	authorRepo  authorRepository.Repository
+	storage     storage.Storage
+	views       *viewBuffer
 }
 
-// NewArticleUseCase use case handler for category
-func NewArticleUseCase(repo repository.Repository) UseCase {
+// NewArticleUseCase use case handler for article
+func NewArticleUseCase(repo repository.Repository, authorRepo authorRepository.Repository, store storage.Storage) UseCase {
 	return &articleUseCase{
 		articleRepo: repo,
+		authorRepo:  authorRepo,
+		storage:     store,
+		views:       newViewBuffer(),
 	}
 }
 
-// Save use case handler for get category by ID
-func (u *articleUseCase) Save(ctx context.Context, param *model.GormArticle) <-chan error {
-	ctxUsecase := "category_usecase_get_by_id"
-	output := make(chan error)
+// Save use case handler for save article and get its persisted state
+func (u *articleUseCase) Save(ctx context.Context, param *model.GormArticle) <-chan ResultUseCase {
+	ctxUsecase := "article_usecase_save"
+	output := make(chan ResultUseCase)
 
 	go func() {
 		defer func() {
 			if r := recover(); r != nil {
 				message := fmt.Sprintf("panic: %v", r)
 				utils.Log(log.ErrorLevel, message, ctxUsecase, "recover_usecase_save")
-				output <- fmt.Errorf(message)
+				output <- ResultUseCase{Error: fmt.Errorf(message)}
 			}
 			close(output)
 		}()
 
-		err := <-u.articleRepo.Save(ctx, param)
-		if err != nil {
-			utils.Log(log.ErrorLevel, err.Error(), ctxUsecase, "res_repo_save")
-			output <- err
+		res := <-u.articleRepo.Save(ctx, param)
+		if res.Error != nil {
+			utils.Log(log.ErrorLevel, res.Error.Error(), ctxUsecase, "res_repo_save")
+			output <- ResultUseCase{Error: res.Error}
 			return
 		}
 
-		output <- nil
+		output <- ResultUseCase{Result: res.Result.(model.Article)}
 	}()
 
 	return output
 }
 
-// GetByID use case handler for get category by ID
-func (u *articleUseCase) GetByID(ctx context.Context, ID int) <-chan ResultUseCase {
-	ctxUsecase := "category_usecase_get_by_id"
+// GetByID use case handler for get article by ID, fields is list of model.ArticleFields to be loaded
+func (u *articleUseCase) GetByID(ctx context.Context, ID int, fields ...string) <-chan ResultUseCase {
+	ctxUsecase := "article_usecase_get_by_id"
 	output := make(chan ResultUseCase)
 
 	go func() {
@@ -65,7 +76,7 @@ func (u *articleUseCase) GetByID(ctx context.Context, ID int) <-chan ResultUseCa
 			close(output)
 		}()
 
-		res := <-u.articleRepo.GetByID(ctx, ID)
+		res := <-u.articleRepo.GetByID(ctx, ID, fields...)
 		if res.Error != nil {
 			utils.Log(log.ErrorLevel, res.Error.Error(), ctxUsecase, "res_repo_get_by_id")
 			output <- ResultUseCase{Error: res.Error}
@@ -74,8 +85,515 @@ func (u *articleUseCase) GetByID(ctx context.Context, ID int) <-chan ResultUseCa
 
 		response := res.Result.(model.Article)
 
+		// unpublished article is hidden from public
+		if response.Status != model.StatusPublished && !shared.CallerFromContext(ctx).IsPrivileged() {
+			output <- ResultUseCase{Error: shared.ErrDataNotFound}
+			return
+		}
+
 		output <- ResultUseCase{Result: response}
 	}()
 
 	return output
 }
+
+// GetBySlug use case handler for get article by its current or old slug, fields is applied the same as GetByID
+func (u *articleUseCase) GetBySlug(ctx context.Context, slug string, fields ...string) <-chan ResultUseCase {
+	ctxUsecase := "article_usecase_get_by_slug"
+	output := make(chan ResultUseCase)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxUsecase, "recover_usecase_get_by_slug")
+				output <- ResultUseCase{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		res := <-u.articleRepo.GetBySlug(ctx, slug, fields...)
+		if res.Error != nil {
+			utils.Log(log.ErrorLevel, res.Error.Error(), ctxUsecase, "res_repo_get_by_slug")
+			output <- ResultUseCase{Error: res.Error}
+			return
+		}
+
+		response := res.Result.(model.Article)
+
+		// unpublished article is hidden from public
+		if response.Status != model.StatusPublished && !shared.CallerFromContext(ctx).IsPrivileged() {
+			output <- ResultUseCase{Error: shared.ErrDataNotFound}
+			return
+		}
+
+		output <- ResultUseCase{Result: response}
+	}()
+
+	return output
+}
+
+// GetAll use case handler for get article list with its total records
+func (u *articleUseCase) GetAll(ctx context.Context, params model.ArticleParams) <-chan ResultUseCase {
+	ctxUsecase := "article_usecase_get_all"
+	output := make(chan ResultUseCase)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxUsecase, "recover_usecase_get_all")
+				output <- ResultUseCase{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		params = visibleParams(ctx, params)
+
+		articlesChan := u.articleRepo.GetAll(ctx, params)
+		totalChan := u.articleRepo.GetTotal(ctx, params)
+
+		resArticles := <-articlesChan
+		resTotal := <-totalChan
+
+		if resArticles.Error != nil {
+			utils.Log(log.ErrorLevel, resArticles.Error.Error(), ctxUsecase, "res_repo_get_all")
+			output <- ResultUseCase{Error: resArticles.Error}
+			return
+		}
+
+		if resTotal.Error != nil {
+			utils.Log(log.ErrorLevel, resTotal.Error.Error(), ctxUsecase, "res_repo_get_total")
+			output <- ResultUseCase{Error: resTotal.Error}
+			return
+		}
+
+		output <- ResultUseCase{Result: model.ArticleList{
+			Data:  resArticles.Result.([]model.Article),
+			Total: resTotal.Result.(int),
+		}}
+	}()
+
+	return output
+}
+
+// GetAllByCursor use case handler for get article list using keyset pagination
+func (u *articleUseCase) GetAllByCursor(ctx context.Context, params model.ArticleParams) <-chan ResultUseCase {
+	ctxUsecase := "article_usecase_get_all_by_cursor"
+	output := make(chan ResultUseCase)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxUsecase, "recover_usecase_get_all_by_cursor")
+				output <- ResultUseCase{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		cursor := params.Cursor
+		if cursor == nil {
+			cursor = new(model.ArticleCursor)
+		}
+
+		params = visibleParams(ctx, params)
+
+		res := <-u.articleRepo.GetAllByCursor(ctx, params)
+		if res.Error != nil {
+			utils.Log(log.ErrorLevel, res.Error.Error(), ctxUsecase, "res_repo_get_all_by_cursor")
+			output <- ResultUseCase{Error: res.Error}
+			return
+		}
+
+		articles := res.Result.([]model.Article)
+
+		// repository returns one extra row when there is more page in the seeking direction
+		hasMore := len(articles) > params.Limit
+		if hasMore {
+			if cursor.Prev {
+				articles = articles[1:]
+			} else {
+				articles = articles[:params.Limit]
+			}
+		}
+
+		result := model.ArticleCursorList{Data: articles}
+		if len(articles) == 0 {
+			// paging past either end still gets a way back, the boundary is moved by one id
+			// so the row of the requested cursor is on the page it leads to
+			switch {
+			case cursor.IsFirst():
+			case cursor.Prev:
+				result.Next = &model.ArticleCursor{Created: cursor.Created, ID: cursor.ID + 1}
+			default:
+				result.Prev = &model.ArticleCursor{Created: cursor.Created, ID: cursor.ID - 1, Prev: true}
+			}
+
+			output <- ResultUseCase{Result: result}
+			return
+		}
+
+		first, last := articles[0], articles[len(articles)-1]
+		if (!cursor.Prev && hasMore) || cursor.Prev {
+			result.Next = &model.ArticleCursor{Created: last.Created, ID: last.ID}
+		}
+
+		if (cursor.Prev && hasMore) || (!cursor.Prev && !cursor.IsFirst()) {
+			result.Prev = &model.ArticleCursor{Created: first.Created, ID: first.ID, Prev: true}
+		}
+
+		output <- ResultUseCase{Result: result}
+	}()
+
+	return output
+}
+
+// Create use case handler for create new article, only privileged caller can create article
+func (u *articleUseCase) Create(ctx context.Context, param model.ArticleRequest) <-chan ResultUseCase {
+	ctxUsecase := "article_usecase_create"
+	output := make(chan ResultUseCase)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxUsecase, "recover_usecase_create")
+				output <- ResultUseCase{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		if !shared.CallerFromContext(ctx).IsPrivileged() {
+			output <- ResultUseCase{Error: shared.ErrForbidden}
+			return
+		}
+
+		if err := u.validateAuthor(ctx, param.AuthorID); err != nil {
+			utils.Log(log.ErrorLevel, err.Error(), ctxUsecase, "validate_author")
+			output <- ResultUseCase{Error: err}
+			return
+		}
+
+		slug, err := u.resolveSlug(ctx, 0, param.Slug, param.Title)
+		if err != nil {
+			utils.Log(log.ErrorLevel, err.Error(), ctxUsecase, "resolve_slug")
+			output <- ResultUseCase{Error: err}
+			return
+		}
+
+		now := time.Now()
+		article := &model.GormArticle{
+			Title:       param.Title,
+			Summary:     param.Summary,
+			Description: param.Description,
+			Image:       param.Image,
+			Slug:        slug,
+			AuthorID:    optionalID(param.AuthorID),
+			Created:     &now,
+			Status:      model.StatusDraft,
+		}
+
+		resSave := <-u.articleRepo.Save(ctx, article)
+		if resSave.Error != nil {
+			utils.Log(log.ErrorLevel, resSave.Error.Error(), ctxUsecase, "res_repo_save")
+			output <- ResultUseCase{Error: resSave.Error}
+			return
+		}
+
+		output <- ResultUseCase{Result: resSave.Result.(model.Article)}
+	}()
+
+	return output
+}
+
+// Update use case handler for replace all field of existing article, only privileged caller can update article
+func (u *articleUseCase) Update(ctx context.Context, ID int, param model.ArticleRequest) <-chan ResultUseCase {
+	ctxUsecase := "article_usecase_update"
+	output := make(chan ResultUseCase)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxUsecase, "recover_usecase_update")
+				output <- ResultUseCase{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		if !shared.CallerFromContext(ctx).IsPrivileged() {
+			output <- ResultUseCase{Error: shared.ErrForbidden}
+			return
+		}
+
+		res := <-u.articleRepo.GetByID(ctx, ID)
+		if res.Error != nil {
+			utils.Log(log.ErrorLevel, res.Error.Error(), ctxUsecase, "res_repo_get_by_id")
+			output <- ResultUseCase{Error: res.Error}
+			return
+		}
+
+		existing := res.Result.(model.Article)
+
+		if err := u.validateAuthor(ctx, param.AuthorID); err != nil {
+			utils.Log(log.ErrorLevel, err.Error(), ctxUsecase, "validate_author")
+			output <- ResultUseCase{Error: err}
+			return
+		}
+
+		// slug is only changed when it is requested explicitly, so published url is kept on title change
+		var slug string
+		if param.Slug != "" && param.Slug != existing.Slug {
+			var err error
+			if slug, err = u.resolveSlug(ctx, existing.ID, param.Slug, param.Title); err != nil {
+				utils.Log(log.ErrorLevel, err.Error(), ctxUsecase, "resolve_slug")
+				output <- ResultUseCase{Error: err}
+				return
+			}
+		}
+
+		now := time.Now()
+		article := &model.GormArticle{
+			ID:          existing.ID,
+			Title:       param.Title,
+			Summary:     param.Summary,
+			Description: param.Description,
+			Image:       param.Image,
+			Slug:        slug,
+			AuthorID:    optionalID(param.AuthorID),
+			Modified:    &now,
+			Version:     param.Version,
+		}
+
+		resSave := <-u.articleRepo.Save(ctx, article)
+		if resSave.Error != nil {
+			utils.Log(log.ErrorLevel, resSave.Error.Error(), ctxUsecase, "res_repo_save")
+			output <- ResultUseCase{Error: resSave.Error}
+			return
+		}
+
+		output <- ResultUseCase{Result: resSave.Result.(model.Article)}
+	}()
+
+	return output
+}
+
+// Patch use case handler for update some field of existing article, only privileged caller can patch article
+func (u *articleUseCase) Patch(ctx context.Context, ID int, param model.ArticlePatchRequest) <-chan ResultUseCase {
+	ctxUsecase := "article_usecase_patch"
+	output := make(chan ResultUseCase)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxUsecase, "recover_usecase_patch")
+				output <- ResultUseCase{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		if !shared.CallerFromContext(ctx).IsPrivileged() {
+			output <- ResultUseCase{Error: shared.ErrForbidden}
+			return
+		}
+
+		res := <-u.articleRepo.GetByID(ctx, ID)
+		if res.Error != nil {
+			utils.Log(log.ErrorLevel, res.Error.Error(), ctxUsecase, "res_repo_get_by_id")
+			output <- ResultUseCase{Error: res.Error}
+			return
+		}
+
+		existing := res.Result.(model.Article)
+
+		// the patch is merged onto existing state, so it must be the state the client has seen
+		if existing.Version != param.Version {
+			output <- ResultUseCase{Error: shared.ErrDataConflict}
+			return
+		}
+
+		now := time.Now()
+		article := &model.GormArticle{
+			ID:          existing.ID,
+			Title:       existing.Title,
+			Summary:     existing.Summary,
+			Description: existing.Description,
+			Image:       existing.Image,
+			AuthorID:    optionalID(existing.AuthorID),
+			Modified:    &now,
+			Version:     param.Version,
+		}
+
+		if param.Title != nil {
+			article.Title = *param.Title
+		}
+
+		if param.Summary != nil {
+			article.Summary = *param.Summary
+		}
+
+		if param.Description != nil {
+			article.Description = *param.Description
+		}
+
+		if param.Image != nil {
+			article.Image = *param.Image
+		}
+
+		if param.AuthorID != nil {
+			if err := u.validateAuthor(ctx, *param.AuthorID); err != nil {
+				utils.Log(log.ErrorLevel, err.Error(), ctxUsecase, "validate_author")
+				output <- ResultUseCase{Error: err}
+				return
+			}
+			article.AuthorID = param.AuthorID
+		}
+
+		if param.Slug != nil && *param.Slug != existing.Slug {
+			slug, err := u.resolveSlug(ctx, existing.ID, *param.Slug, article.Title)
+			if err != nil {
+				utils.Log(log.ErrorLevel, err.Error(), ctxUsecase, "resolve_slug")
+				output <- ResultUseCase{Error: err}
+				return
+			}
+			article.Slug = slug
+		}
+
+		resSave := <-u.articleRepo.Save(ctx, article)
+		if resSave.Error != nil {
+			utils.Log(log.ErrorLevel, resSave.Error.Error(), ctxUsecase, "res_repo_save")
+			output <- ResultUseCase{Error: resSave.Error}
+			return
+		}
+
+		output <- ResultUseCase{Result: resSave.Result.(model.Article)}
+	}()
+
+	return output
+}
+
+// Delete use case handler for delete article by ID, only admin can delete article
+func (u *articleUseCase) Delete(ctx context.Context, ID int) <-chan error {
+	ctxUsecase := "article_usecase_delete"
+	output := make(chan error)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxUsecase, "recover_usecase_delete")
+				output <- fmt.Errorf(message)
+			}
+			close(output)
+		}()
+
+		if !shared.CallerFromContext(ctx).IsAdmin() {
+			output <- shared.ErrForbidden
+			return
+		}
+
+		err := <-u.articleRepo.Delete(ctx, ID)
+		if err != nil {
+			utils.Log(log.ErrorLevel, err.Error(), ctxUsecase, "res_repo_delete")
+			output <- err
+			return
+		}
+
+		output <- nil
+	}()
+
+	return output
+}
+
+// Restore use case handler for restore soft deleted article by ID, only admin can restore article
+func (u *articleUseCase) Restore(ctx context.Context, ID int) <-chan ResultUseCase {
+	ctxUsecase := "article_usecase_restore"
+	output := make(chan ResultUseCase)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxUsecase, "recover_usecase_restore")
+				output <- ResultUseCase{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		if !shared.CallerFromContext(ctx).IsAdmin() {
+			output <- ResultUseCase{Error: shared.ErrForbidden}
+			return
+		}
+
+		res := <-u.articleRepo.Restore(ctx, ID)
+		if res.Error != nil {
+			utils.Log(log.ErrorLevel, res.Error.Error(), ctxUsecase, "res_repo_restore")
+			output <- ResultUseCase{Error: res.Error}
+			return
+		}
+
+		output <- ResultUseCase{Result: res.Result.(model.Article)}
+	}()
+
+	return output
+}
+
+// resolveSlug function for getting slug of article, requested slug must not be used by other article,
+// otherwise the slug is generated from title and suffixed by number when it is already used
+func (u *articleUseCase) resolveSlug(ctx context.Context, articleID int, requested, title string) (string, error) {
+	res := <-u.articleRepo.GetAvailableSlug(ctx, slugBase(requested, title), articleID)
+	if res.Error != nil {
+		return "", res.Error
+	}
+
+	slug := res.Result.(string)
+	if requested != "" && slug != requested {
+		return "", model.ErrSlugUsed
+	}
+
+	return slug, nil
+}
+
+// slugBase function for getting slug that is suffixed when it is taken, requested slug or slug of title
+func slugBase(requested, title string) string {
+	if requested != "" {
+		return requested
+	}
+
+	if base := shared.Slugify(title); base != "" {
+		return base
+	}
+	return "article"
+}
+
+// validateAuthor function for checking that requested author of article exists, zero id means no author is requested
+func (u *articleUseCase) validateAuthor(ctx context.Context, authorID int) error {
+	if authorID <= 0 {
+		return nil
+	}
+
+	res := <-u.authorRepo.GetByID(ctx, authorID)
+	if errors.Is(res.Error, shared.ErrDataNotFound) {
+		return model.ErrAuthorNotFound
+	}
+	return res.Error
+}
+
+// optionalID function for converting zero id into nil
+func optionalID(ID int) *int {
+	if ID <= 0 {
+		return nil
+	}
+	return &ID
+}
+
+// visibleParams function for limiting article list params into articles that caller can see,
+// public caller can only see published articles
+func visibleParams(ctx context.Context, params model.ArticleParams) model.ArticleParams {
+	if !shared.CallerFromContext(ctx).IsPrivileged() {
+		params.Status = []string{model.StatusPublished}
+	}
+	return params
+}
@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/willy182/boilerplate-go-cleanarch/src/articles/v1/model"
+	authorModel "github.com/willy182/boilerplate-go-cleanarch/src/authors/v1/model"
+	"github.com/willy182/boilerplate-go-cleanarch/utils"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// GetAllByAuthor use case handler for get article list of author with its total records
+func (u *articleUseCase) GetAllByAuthor(ctx context.Context, authorID int, params model.ArticleParams) <-chan ResultUseCase {
+	ctxUsecase := "article_usecase_get_all_by_author"
+	output := make(chan ResultUseCase)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxUsecase, "recover_usecase_get_all_by_author")
+				output <- ResultUseCase{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		resAuthor := <-u.authorRepo.GetByID(ctx, authorID)
+		if resAuthor.Error != nil {
+			utils.Log(log.ErrorLevel, resAuthor.Error.Error(), ctxUsecase, "res_repo_get_author_by_id")
+			output <- ResultUseCase{Error: resAuthor.Error}
+			return
+		}
+
+		params.AuthorID = authorID
+		output <- <-u.GetAll(ctx, params)
+	}()
+
+	return output
+}
+
+// loadAuthors function for filling author of articles, contact of author is not part of article byline
+func (u *articleUseCase) loadAuthors(ctx context.Context, articles []model.Article) error {
+	ids := make([]int, 0, len(articles))
+	for _, article := range articles {
+		if article.AuthorID > 0 {
+			ids = append(ids, article.AuthorID)
+		}
+	}
+
+	res := <-u.authorRepo.GetByIDs(ctx, ids)
+	if res.Error != nil {
+		return res.Error
+	}
+
+	authors := res.Result.(map[int]authorModel.Author)
+	for i := range articles {
+		if author, ok := authors[articles[i].AuthorID]; ok {
+			author.Email = ""
+			articles[i].Author = &author
+		}
+	}
+
+	return nil
+}
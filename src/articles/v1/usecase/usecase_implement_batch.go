@@ -0,0 +1,210 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/willy182/boilerplate-go-cleanarch/src/articles/v1/model"
+	"github.com/willy182/boilerplate-go-cleanarch/src/shared"
+	"github.com/willy182/boilerplate-go-cleanarch/utils"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Batch use case handler for applying create, update, delete and status operations in single transaction,
+// operations are validated against the state before the batch, atomic batch applies nothing when any operation fails,
+// otherwise only the failed operations are skipped, result of each operation is returned in the same order,
+// delete operation requires admin the same as Delete
+func (u *articleUseCase) Batch(ctx context.Context, param model.ArticleBatchRequest) <-chan ResultUseCase {
+	ctxUsecase := "article_usecase_batch"
+	output := make(chan ResultUseCase)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxUsecase, "recover_usecase_batch")
+				output <- ResultUseCase{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		if !shared.CallerFromContext(ctx).IsPrivileged() {
+			output <- ResultUseCase{Error: shared.ErrForbidden}
+			return
+		}
+
+		atomic := param.Mode != model.BatchModeItem
+		results := make([]model.ArticleBatchResult, len(param.Operations))
+		items := make([]model.ArticleBatchItem, 0, len(param.Operations))
+
+		// slugs taken by previous operations, nothing is saved yet for GetAvailableSlug to see
+		slugs := make(map[string]bool)
+		failed := false
+		for i, op := range param.Operations {
+			results[i] = model.ArticleBatchResult{Index: i, Action: op.Action, ID: op.ID}
+
+			item, err := u.prepareBatchItem(ctx, op, slugs)
+			if err != nil {
+				utils.Log(log.ErrorLevel, err.Error(), ctxUsecase, "prepare_batch_item")
+				results[i].Err = err
+				failed = true
+				continue
+			}
+
+			item.Index = i
+			items = append(items, item)
+		}
+
+		if failed && atomic {
+			for i := range results {
+				if results[i].Err == nil {
+					results[i].Err = model.ErrBatchRolledBack
+				}
+			}
+			output <- ResultUseCase{Result: results}
+			return
+		}
+
+		if len(items) > 0 {
+			res := <-u.articleRepo.Batch(ctx, items, atomic)
+			if res.Error != nil {
+				utils.Log(log.ErrorLevel, res.Error.Error(), ctxUsecase, "res_repo_batch")
+				output <- ResultUseCase{Error: res.Error}
+				return
+			}
+
+			for _, result := range res.Result.([]model.ArticleBatchResult) {
+				results[result.Index] = result
+			}
+		}
+
+		output <- ResultUseCase{Result: results}
+	}()
+
+	return output
+}
+
+// prepareBatchItem function for validating single batch operation the same way as its own use case
+// and converting it into batch item that is applied by repository
+func (u *articleUseCase) prepareBatchItem(ctx context.Context, op model.ArticleBatchOperation, slugs map[string]bool) (model.ArticleBatchItem, error) {
+	item := model.ArticleBatchItem{Action: op.Action, ID: op.ID, Status: op.Status, Version: op.Version}
+	if op.Err != nil {
+		return item, op.Err
+	}
+
+	switch op.Action {
+	case model.BatchDelete:
+		if !shared.CallerFromContext(ctx).IsAdmin() {
+			return item, shared.ErrForbidden
+		}
+		return item, nil
+
+	case model.BatchStatus:
+		res := <-u.articleRepo.GetByID(ctx, op.ID, "id", "status")
+		if res.Error != nil {
+			return item, res.Error
+		}
+
+		existing := res.Result.(model.Article)
+		return item, checkTransition(shared.CallerFromContext(ctx), existing.Status, op.Status)
+	}
+
+	var err error
+	if item.Tags, err = buildTerms("tag", op.Tags); err != nil {
+		return item, err
+	}
+
+	if item.Categories, err = buildTerms("category", op.Categories); err != nil {
+		return item, err
+	}
+
+	if err := u.validateAuthor(ctx, op.Article.AuthorID); err != nil {
+		return item, err
+	}
+
+	now := time.Now()
+	item.Article = &model.GormArticle{
+		Title:       op.Article.Title,
+		Summary:     op.Article.Summary,
+		Description: op.Article.Description,
+		Image:       op.Article.Image,
+		AuthorID:    optionalID(op.Article.AuthorID),
+	}
+
+	if op.Action == model.BatchCreate {
+		slug, err := u.batchSlug(ctx, op.Article.Slug, op.Article.Title, slugs)
+		if err != nil {
+			return item, err
+		}
+		slugs[slug] = true
+
+		item.Article.Slug = slug
+		item.Article.Created = &now
+		item.Article.Status = model.StatusDraft
+		return item, nil
+	}
+
+	res := <-u.articleRepo.GetByID(ctx, op.ID, "id", "slug")
+	if res.Error != nil {
+		return item, res.Error
+	}
+	existing := res.Result.(model.Article)
+
+	// slug is only changed when it is requested explicitly, so published url is kept on title change
+	if op.Article.Slug != "" && op.Article.Slug != existing.Slug {
+		if slugs[op.Article.Slug] {
+			return item, model.ErrSlugUsed
+		}
+
+		slug, err := u.resolveSlug(ctx, existing.ID, op.Article.Slug, op.Article.Title)
+		if err != nil {
+			return item, err
+		}
+		slugs[slug] = true
+		item.Article.Slug = slug
+	}
+
+	item.Article.ID = existing.ID
+	item.Article.Modified = &now
+	item.Article.Version = op.Version
+	return item, nil
+}
+
+// batchSlug function for resolving slug of created article that is not taken by previous operation of the batch either,
+// previous operations are not saved yet so GetAvailableSlug can not see them and the next free suffix is searched here
+func (u *articleUseCase) batchSlug(ctx context.Context, requested, title string, slugs map[string]bool) (string, error) {
+	slug, err := u.resolveSlug(ctx, 0, requested, title)
+	if err != nil {
+		return "", err
+	}
+
+	base := slugBase(requested, title)
+	for i := 2; slugs[slug]; i++ {
+		if requested != "" {
+			return "", model.ErrSlugUsed
+		}
+
+		suffix := "-" + strconv.Itoa(i)
+		candidate := base
+		if len(candidate) > shared.SlugMaxLength-len(suffix) {
+			candidate = candidate[:shared.SlugMaxLength-len(suffix)]
+		}
+		candidate = strings.TrimRight(candidate, "-") + suffix
+
+		// candidate is only taken when it is not used by a saved article either
+		res := <-u.articleRepo.GetAvailableSlug(ctx, candidate, 0)
+		if res.Error != nil {
+			return "", res.Error
+		}
+
+		if res.Result.(string) == candidate {
+			slug = candidate
+		}
+	}
+
+	return slug, nil
+}
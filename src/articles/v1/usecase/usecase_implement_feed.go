@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/willy182/boilerplate-go-cleanarch/src/articles/v1/model"
+	authorModel "github.com/willy182/boilerplate-go-cleanarch/src/authors/v1/model"
+	"github.com/willy182/boilerplate-go-cleanarch/utils"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// GetFeed use case handler for get latest published articles of feed with their author, tags and categories,
+// feed is always public so draft and archived articles are never part of it whoever the caller is
+func (u *articleUseCase) GetFeed(ctx context.Context, params model.ArticleFeedParams) <-chan ResultUseCase {
+	ctxUsecase := "article_usecase_get_feed"
+	output := make(chan ResultUseCase)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxUsecase, "recover_usecase_get_feed")
+				output <- ResultUseCase{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		feed := model.ArticleFeed{Title: "Articles"}
+		if params.Tag != "" {
+			feed.Title = fmt.Sprintf("Articles tagged %s", params.Tag)
+		}
+
+		if params.AuthorID > 0 {
+			resAuthor := <-u.authorRepo.GetByID(ctx, params.AuthorID)
+			if resAuthor.Error != nil {
+				utils.Log(log.ErrorLevel, resAuthor.Error.Error(), ctxUsecase, "res_repo_get_author_by_id")
+				output <- ResultUseCase{Error: resAuthor.Error}
+				return
+			}
+			feed.Title = fmt.Sprintf("Articles by %s", resAuthor.Result.(authorModel.Author).Name)
+		}
+
+		articleParams := model.ArticleParams{
+			Page:     1,
+			Limit:    model.FeedLimit,
+			Status:   []string{model.StatusPublished},
+			Tag:      params.Tag,
+			AuthorID: params.AuthorID,
+			Sort:     []model.SortField{{Field: "publishedAt", Desc: true}},
+		}
+		articlesChan := u.articleRepo.GetAll(ctx, articleParams)
+		lastModifiedChan := u.articleRepo.GetLastModified(ctx, articleParams)
+
+		res := <-articlesChan
+		resLastModified := <-lastModifiedChan
+
+		if res.Error != nil {
+			utils.Log(log.ErrorLevel, res.Error.Error(), ctxUsecase, "res_repo_get_all")
+			output <- ResultUseCase{Error: res.Error}
+			return
+		}
+
+		if resLastModified.Error != nil {
+			utils.Log(log.ErrorLevel, resLastModified.Error.Error(), ctxUsecase, "res_repo_get_last_modified")
+			output <- ResultUseCase{Error: resLastModified.Error}
+			return
+		}
+		feed.LastModified = resLastModified.Result.(time.Time)
+
+		feed.Articles = res.Result.([]model.Article)
+		if len(feed.Articles) > 0 {
+			if err := u.loadAuthors(ctx, feed.Articles); err != nil {
+				utils.Log(log.ErrorLevel, err.Error(), ctxUsecase, "load_authors")
+				output <- ResultUseCase{Error: err}
+				return
+			}
+
+			if err := u.loadTaxonomies(ctx, feed.Articles); err != nil {
+				utils.Log(log.ErrorLevel, err.Error(), ctxUsecase, "load_taxonomies")
+				output <- ResultUseCase{Error: err}
+				return
+			}
+		}
+
+		output <- ResultUseCase{Result: feed}
+	}()
+
+	return output
+}
@@ -0,0 +1,159 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"net/http"
+	"time"
+
+	// decoder of supported image type
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+
+	"github.com/willy182/boilerplate-go-cleanarch/src/articles/v1/model"
+	"github.com/willy182/boilerplate-go-cleanarch/src/shared"
+	"github.com/willy182/boilerplate-go-cleanarch/utils"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// imageDecodeSlots semaphore of image decoding, it bounds memory of concurrent uploads to model.ImageMaxDecodes images
+var imageDecodeSlots = make(chan struct{}, model.ImageMaxDecodes)
+
+// UploadImage use case handler for storing uploaded article image with its resized variants,
+// variant wider than the original image is not upscaled and points to the original image instead
+func (u *articleUseCase) UploadImage(ctx context.Context, ID int, param model.ArticleImageUpload) <-chan ResultUseCase {
+	ctxUsecase := "article_usecase_upload_image"
+	output := make(chan ResultUseCase)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxUsecase, "recover_usecase_upload_image")
+				output <- ResultUseCase{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		if !shared.CallerFromContext(ctx).IsPrivileged() {
+			output <- ResultUseCase{Error: shared.ErrForbidden}
+			return
+		}
+
+		if len(param.Data) > model.ImageMaxSize {
+			output <- ResultUseCase{Error: model.ErrImageTooLarge}
+			return
+		}
+
+		// the type is sniffed from the content, the client supplied filename and content type are not trusted
+		contentType := http.DetectContentType(param.Data)
+		ext, ok := model.ImageTypes[contentType]
+		if !ok {
+			output <- ResultUseCase{Error: model.ErrImageType}
+			return
+		}
+
+		config, _, err := image.DecodeConfig(bytes.NewReader(param.Data))
+		if err != nil {
+			output <- ResultUseCase{Error: model.ErrImageType}
+			return
+		}
+
+		if config.Width*config.Height > model.ImageMaxPixels {
+			output <- ResultUseCase{Error: model.ErrImageDimension}
+			return
+		}
+
+		res := <-u.articleRepo.GetByID(ctx, ID, "id")
+		if res.Error != nil {
+			utils.Log(log.ErrorLevel, res.Error.Error(), ctxUsecase, "res_repo_get_by_id")
+			output <- ResultUseCase{Error: res.Error}
+			return
+		}
+
+		select {
+		case imageDecodeSlots <- struct{}{}:
+			defer func() { <-imageDecodeSlots }()
+		case <-ctx.Done():
+			output <- ResultUseCase{Error: ctx.Err()}
+			return
+		}
+
+		img, _, err := image.Decode(bytes.NewReader(param.Data))
+		if err != nil {
+			output <- ResultUseCase{Error: model.ErrImageType}
+			return
+		}
+
+		// every upload gets its own prefix so cached urls of the previous image stay valid
+		prefix := fmt.Sprintf("articles/%d/%d", ID, time.Now().UnixNano())
+		var keys []string
+
+		put := func(key, contentType string, body []byte) (string, error) {
+			url, err := u.storage.Put(ctx, key, contentType, body)
+			if err == nil {
+				keys = append(keys, key)
+			}
+			return url, err
+		}
+
+		original, err := put(prefix+"/original."+ext, contentType, param.Data)
+		if err != nil {
+			utils.Log(log.ErrorLevel, err.Error(), ctxUsecase, "storage_put_original")
+			output <- ResultUseCase{Error: err}
+			return
+		}
+
+		// gif variant is encoded as png, only the first frame of animated gif is kept
+		isJPEG := contentType == "image/jpeg"
+		variantExt, variantType := "png", "image/png"
+		if isJPEG {
+			variantExt, variantType = "jpg", "image/jpeg"
+		}
+
+		variants := make(map[string]string, len(model.ImageVariants))
+		for _, variant := range model.ImageVariants {
+			if variant.Width >= config.Width {
+				variants[variant.Name] = original
+				continue
+			}
+
+			body, err := shared.EncodeImage(shared.ResizeImage(img, variant.Width), isJPEG)
+			if err == nil {
+				variants[variant.Name], err = put(prefix+"/"+variant.Name+"."+variantExt, variantType, body)
+			}
+
+			if err != nil {
+				utils.Log(log.ErrorLevel, err.Error(), ctxUsecase, "storage_put_variant")
+				u.deleteImages(ctx, ctxUsecase, keys)
+				output <- ResultUseCase{Error: err}
+				return
+			}
+		}
+
+		res = <-u.articleRepo.UpdateImage(ctx, ID, original, variants)
+		if res.Error != nil {
+			utils.Log(log.ErrorLevel, res.Error.Error(), ctxUsecase, "res_repo_update_image")
+			u.deleteImages(ctx, ctxUsecase, keys)
+			output <- ResultUseCase{Error: res.Error}
+			return
+		}
+
+		output <- ResultUseCase{Result: res.Result.(model.Article)}
+	}()
+
+	return output
+}
+
+// deleteImages function for removing stored images of failed upload, the failure is only logged
+func (u *articleUseCase) deleteImages(ctx context.Context, ctxUsecase string, keys []string) {
+	for _, key := range keys {
+		if err := u.storage.Delete(ctx, key); err != nil {
+			utils.Log(log.ErrorLevel, err.Error(), ctxUsecase, "storage_delete")
+		}
+	}
+}
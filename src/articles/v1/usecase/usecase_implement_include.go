@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/willy182/boilerplate-go-cleanarch/src/articles/v1/model"
+	"github.com/willy182/boilerplate-go-cleanarch/src/shared"
+	"github.com/willy182/boilerplate-go-cleanarch/utils"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Include use case handler for expanding relations of articles, include is list of ArticleIncludes
+func (u *articleUseCase) Include(ctx context.Context, articles []model.Article, include []string) <-chan ResultUseCase {
+	ctxUsecase := "article_usecase_include"
+	output := make(chan ResultUseCase)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxUsecase, "recover_usecase_include")
+				output <- ResultUseCase{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		if shared.StringInSlice(model.IncludeAuthor, include) {
+			if err := u.loadAuthors(ctx, articles); err != nil {
+				utils.Log(log.ErrorLevel, err.Error(), ctxUsecase, "load_authors")
+				output <- ResultUseCase{Error: err}
+				return
+			}
+		}
+
+		includeTags := shared.StringInSlice(model.IncludeTags, include)
+		includeCategories := shared.StringInSlice(model.IncludeCategories, include)
+		if includeTags || includeCategories {
+			if err := u.loadTaxonomies(ctx, articles); err != nil {
+				utils.Log(log.ErrorLevel, err.Error(), ctxUsecase, "load_taxonomies")
+				output <- ResultUseCase{Error: err}
+				return
+			}
+
+			// tags and categories are loaded together, only the requested one is kept
+			for i := range articles {
+				if !includeTags {
+					articles[i].Tags = nil
+				}
+
+				if !includeCategories {
+					articles[i].Categories = nil
+				}
+			}
+		}
+
+		output <- ResultUseCase{Result: articles}
+	}()
+
+	return output
+}
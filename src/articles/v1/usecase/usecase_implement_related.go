@@ -0,0 +1,47 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/willy182/boilerplate-go-cleanarch/src/articles/v1/model"
+	"github.com/willy182/boilerplate-go-cleanarch/utils"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// GetRelated use case handler for get at most limit published articles related to article,
+// the article itself must be visible to caller
+func (u *articleUseCase) GetRelated(ctx context.Context, ID, limit int) <-chan ResultUseCase {
+	ctxUsecase := "article_usecase_get_related"
+	output := make(chan ResultUseCase)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxUsecase, "recover_usecase_get_related")
+				output <- ResultUseCase{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		resArticle := <-u.GetByID(ctx, ID, "id")
+		if resArticle.Error != nil {
+			utils.Log(log.ErrorLevel, resArticle.Error.Error(), ctxUsecase, "res_get_by_id")
+			output <- ResultUseCase{Error: resArticle.Error}
+			return
+		}
+
+		res := <-u.articleRepo.GetRelated(ctx, ID, limit)
+		if res.Error != nil {
+			utils.Log(log.ErrorLevel, res.Error.Error(), ctxUsecase, "res_repo_get_related")
+			output <- ResultUseCase{Error: res.Error}
+			return
+		}
+
+		output <- ResultUseCase{Result: res.Result.([]model.Article)}
+	}()
+
+	return output
+}
@@ -0,0 +1,242 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/willy182/boilerplate-go-cleanarch/src/articles/v1/model"
+	"github.com/willy182/boilerplate-go-cleanarch/src/shared"
+	"github.com/willy182/boilerplate-go-cleanarch/utils"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// GetRevisions use case handler for get revision history of article
+func (u *articleUseCase) GetRevisions(ctx context.Context, ID int) <-chan ResultUseCase {
+	ctxUsecase := "article_usecase_get_revisions"
+	output := make(chan ResultUseCase)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxUsecase, "recover_usecase_get_revisions")
+				output <- ResultUseCase{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		// revision history is editorial data
+		if !shared.CallerFromContext(ctx).IsPrivileged() {
+			output <- ResultUseCase{Error: shared.ErrForbidden}
+			return
+		}
+
+		articleChan := u.articleRepo.GetByID(ctx, ID)
+		revisionsChan := u.articleRepo.GetRevisions(ctx, ID)
+
+		resArticle := <-articleChan
+		resRevisions := <-revisionsChan
+
+		if resArticle.Error != nil {
+			utils.Log(log.ErrorLevel, resArticle.Error.Error(), ctxUsecase, "res_repo_get_by_id")
+			output <- ResultUseCase{Error: resArticle.Error}
+			return
+		}
+
+		if resRevisions.Error != nil {
+			utils.Log(log.ErrorLevel, resRevisions.Error.Error(), ctxUsecase, "res_repo_get_revisions")
+			output <- ResultUseCase{Error: resRevisions.Error}
+			return
+		}
+
+		output <- ResultUseCase{Result: resRevisions.Result.([]model.ArticleRevision)}
+	}()
+
+	return output
+}
+
+// GetRevision use case handler for get single revision of article
+func (u *articleUseCase) GetRevision(ctx context.Context, ID, revision int) <-chan ResultUseCase {
+	ctxUsecase := "article_usecase_get_revision"
+	output := make(chan ResultUseCase)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxUsecase, "recover_usecase_get_revision")
+				output <- ResultUseCase{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		// revision history is editorial data
+		if !shared.CallerFromContext(ctx).IsPrivileged() {
+			output <- ResultUseCase{Error: shared.ErrForbidden}
+			return
+		}
+
+		res := <-u.articleRepo.GetRevision(ctx, ID, revision)
+		if res.Error != nil {
+			utils.Log(log.ErrorLevel, res.Error.Error(), ctxUsecase, "res_repo_get_revision")
+			output <- ResultUseCase{Error: res.Error}
+			return
+		}
+
+		output <- ResultUseCase{Result: res.Result.(model.ArticleRevision)}
+	}()
+
+	return output
+}
+
+// DiffRevisions use case handler for get field level difference between two revisions of article
+func (u *articleUseCase) DiffRevisions(ctx context.Context, ID, from, to int) <-chan ResultUseCase {
+	ctxUsecase := "article_usecase_diff_revisions"
+	output := make(chan ResultUseCase)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxUsecase, "recover_usecase_diff_revisions")
+				output <- ResultUseCase{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		// revision history is editorial data
+		if !shared.CallerFromContext(ctx).IsPrivileged() {
+			output <- ResultUseCase{Error: shared.ErrForbidden}
+			return
+		}
+
+		fromChan := u.articleRepo.GetRevision(ctx, ID, from)
+		toChan := u.articleRepo.GetRevision(ctx, ID, to)
+
+		resFrom := <-fromChan
+		resTo := <-toChan
+
+		if resFrom.Error != nil {
+			utils.Log(log.ErrorLevel, resFrom.Error.Error(), ctxUsecase, "res_repo_get_revision_from")
+			output <- ResultUseCase{Error: resFrom.Error}
+			return
+		}
+
+		if resTo.Error != nil {
+			utils.Log(log.ErrorLevel, resTo.Error.Error(), ctxUsecase, "res_repo_get_revision_to")
+			output <- ResultUseCase{Error: resTo.Error}
+			return
+		}
+
+		revFrom := resFrom.Result.(model.ArticleRevision)
+		revTo := resTo.Result.(model.ArticleRevision)
+
+		diff := model.ArticleRevisionDiff{ArticleID: ID, From: from, To: to, Changes: []model.FieldChange{}}
+		for _, field := range []struct {
+			name     string
+			from, to string
+		}{
+			{"title", revFrom.Title, revTo.Title},
+			{"summary", revFrom.Summary, revTo.Summary},
+			{"description", revFrom.Description, revTo.Description},
+			{"image", revFrom.Image, revTo.Image},
+			{"status", revFrom.Status, revTo.Status},
+			{"slug", revFrom.Slug, revTo.Slug},
+			{"authorId", optionalIDString(revFrom.AuthorID), optionalIDString(revTo.AuthorID)},
+		} {
+			if field.from != field.to {
+				diff.Changes = append(diff.Changes, model.FieldChange{Field: field.name, From: field.from, To: field.to})
+			}
+		}
+
+		output <- ResultUseCase{Result: diff}
+	}()
+
+	return output
+}
+
+// RestoreRevision use case handler for rolling back article into one of its revision,
+// the rollback is saved as a new revision
+func (u *articleUseCase) RestoreRevision(ctx context.Context, ID, revision, version int) <-chan ResultUseCase {
+	ctxUsecase := "article_usecase_restore_revision"
+	output := make(chan ResultUseCase)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxUsecase, "recover_usecase_restore_revision")
+				output <- ResultUseCase{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		// revision history is editorial data
+		if !shared.CallerFromContext(ctx).IsPrivileged() {
+			output <- ResultUseCase{Error: shared.ErrForbidden}
+			return
+		}
+
+		articleChan := u.articleRepo.GetByID(ctx, ID)
+		revisionChan := u.articleRepo.GetRevision(ctx, ID, revision)
+
+		resArticle := <-articleChan
+		resRevision := <-revisionChan
+
+		if resArticle.Error != nil {
+			utils.Log(log.ErrorLevel, resArticle.Error.Error(), ctxUsecase, "res_repo_get_by_id")
+			output <- ResultUseCase{Error: resArticle.Error}
+			return
+		}
+
+		if resRevision.Error != nil {
+			utils.Log(log.ErrorLevel, resRevision.Error.Error(), ctxUsecase, "res_repo_get_revision")
+			output <- ResultUseCase{Error: resRevision.Error}
+			return
+		}
+
+		rev := resRevision.Result.(model.ArticleRevision)
+
+		// slug and author are rolled back too, status is left to the workflow,
+		// revision written before its state was recorded has no status and keeps the current author
+		authorID := rev.AuthorID
+		if rev.Status == "" {
+			authorID = resArticle.Result.(model.Article).AuthorID
+		}
+
+		now := time.Now()
+		article := &model.GormArticle{
+			ID:          ID,
+			Title:       rev.Title,
+			Summary:     rev.Summary,
+			Description: rev.Description,
+			Image:       rev.Image,
+			Slug:        rev.Slug,
+			AuthorID:    optionalID(authorID),
+			Modified:    &now,
+			Version:     version,
+		}
+
+		resSave := <-u.articleRepo.Save(ctx, article)
+		if resSave.Error != nil {
+			utils.Log(log.ErrorLevel, resSave.Error.Error(), ctxUsecase, "res_repo_save")
+			output <- ResultUseCase{Error: resSave.Error}
+			return
+		}
+
+		output <- ResultUseCase{Result: resSave.Result.(model.Article)}
+	}()
+
+	return output
+}
+
+// optionalIDString function for formatting optional id of revision, zero id is empty
+func optionalIDString(ID int) string {
+	if ID <= 0 {
+		return ""
+	}
+	return strconv.Itoa(ID)
+}
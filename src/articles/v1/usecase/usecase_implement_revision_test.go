@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/willy182/boilerplate-go-cleanarch/src/articles/v1/model"
+	"github.com/willy182/boilerplate-go-cleanarch/src/articles/v1/repository"
+	"github.com/willy182/boilerplate-go-cleanarch/src/shared"
+)
+
+// revisionArticleRepo article repository which also serves revisions keyed by revision number
+type revisionArticleRepo struct {
+	stubArticleRepo
+	revisions map[int]model.ArticleRevision
+}
+
+func (r *revisionArticleRepo) GetRevision(ctx context.Context, articleID, revision int) <-chan repository.ResultRepository {
+	output := make(chan repository.ResultRepository, 1)
+	if rev, ok := r.revisions[revision]; ok {
+		output <- repository.ResultRepository{Result: rev}
+	} else {
+		output <- repository.ResultRepository{Error: shared.ErrDataNotFound}
+	}
+	close(output)
+	return output
+}
+
+func TestDiffRevisionsState(t *testing.T) {
+	repo := &revisionArticleRepo{revisions: map[int]model.ArticleRevision{
+		1: {ArticleID: 3, Revision: 1, Title: "title", Status: model.StatusDraft, Slug: "title"},
+		2: {ArticleID: 3, Revision: 2, Title: "title", Status: model.StatusPublished, Slug: "new-title", AuthorID: 9},
+	}}
+	u := &articleUseCase{articleRepo: repo}
+
+	res := <-u.DiffRevisions(editorContext(), 3, 1, 2)
+	if res.Error != nil {
+		t.Fatalf("diff error = %v", res.Error)
+	}
+
+	expected := []model.FieldChange{
+		{Field: "status", From: model.StatusDraft, To: model.StatusPublished},
+		{Field: "slug", From: "title", To: "new-title"},
+		{Field: "authorId", From: "", To: "9"},
+	}
+	if changes := res.Result.(model.ArticleRevisionDiff).Changes; !reflect.DeepEqual(changes, expected) {
+		t.Errorf("changes = %v, expected %v", changes, expected)
+	}
+}
+
+func TestRestoreRevisionState(t *testing.T) {
+	existing := model.Article{ID: 3, Title: "current", Summary: "summary", Slug: "current", AuthorID: 9, Version: 5}
+
+	tests := []struct {
+		name       string
+		revision   model.ArticleRevision
+		wantSlug   string
+		wantAuthor *int
+	}{
+		{
+			name:       "author and slug are rolled back",
+			revision:   model.ArticleRevision{Revision: 2, Title: "old", Status: model.StatusDraft, Slug: "old", AuthorID: 4},
+			wantSlug:   "old",
+			wantAuthor: optionalID(4),
+		},
+		{
+			name:     "revision without author clears it",
+			revision: model.ArticleRevision{Revision: 2, Title: "old", Status: model.StatusDraft, Slug: "old"},
+			wantSlug: "old",
+		},
+		{
+			name:       "revision written before its state keeps current author and slug",
+			revision:   model.ArticleRevision{Revision: 2, Title: "old"},
+			wantAuthor: optionalID(existing.AuthorID),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &revisionArticleRepo{
+				stubArticleRepo: stubArticleRepo{articles: []model.Article{existing}},
+				revisions:       map[int]model.ArticleRevision{2: tt.revision},
+			}
+			u := &articleUseCase{articleRepo: repo}
+
+			if res := <-u.RestoreRevision(editorContext(), 3, 2, 5); res.Error != nil {
+				t.Fatalf("restore error = %v", res.Error)
+			}
+
+			if repo.saved.Slug != tt.wantSlug {
+				t.Errorf("Slug = %q, want %q", repo.saved.Slug, tt.wantSlug)
+			}
+
+			got := repo.saved.AuthorID
+			if (got == nil) != (tt.wantAuthor == nil) || (got != nil && *got != *tt.wantAuthor) {
+				t.Errorf("AuthorID = %v, want %v", got, tt.wantAuthor)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/willy182/boilerplate-go-cleanarch/src/articles/v1/model"
+	"github.com/willy182/boilerplate-go-cleanarch/utils"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Search use case handler for full text search of article with its total records
+func (u *articleUseCase) Search(ctx context.Context, params model.ArticleParams) <-chan ResultUseCase {
+	ctxUsecase := "article_usecase_search"
+	output := make(chan ResultUseCase)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxUsecase, "recover_usecase_search")
+				output <- ResultUseCase{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		params = visibleParams(ctx, params)
+
+		resultsChan := u.articleRepo.Search(ctx, params)
+		totalChan := u.articleRepo.SearchTotal(ctx, params)
+
+		resResults := <-resultsChan
+		resTotal := <-totalChan
+
+		if resResults.Error != nil {
+			utils.Log(log.ErrorLevel, resResults.Error.Error(), ctxUsecase, "res_repo_search")
+			output <- ResultUseCase{Error: resResults.Error}
+			return
+		}
+
+		if resTotal.Error != nil {
+			utils.Log(log.ErrorLevel, resTotal.Error.Error(), ctxUsecase, "res_repo_search_total")
+			output <- ResultUseCase{Error: resTotal.Error}
+			return
+		}
+
+		output <- ResultUseCase{Result: model.ArticleSearchList{
+			Data:  resResults.Result.([]model.ArticleSearchResult),
+			Total: resTotal.Result.(int),
+		}}
+	}()
+
+	return output
+}
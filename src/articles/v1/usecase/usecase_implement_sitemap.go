@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/willy182/boilerplate-go-cleanarch/src/articles/v1/model"
+	"github.com/willy182/boilerplate-go-cleanarch/src/shared"
+	"github.com/willy182/boilerplate-go-cleanarch/utils"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// GetSitemap use case handler for get sitemap page of published articles whose id is greater than after,
+// model.SitemapRoot is the root sitemap which lists the articles directly when they fit into a single sitemap,
+// otherwise it is the sitemap index
+func (u *articleUseCase) GetSitemap(ctx context.Context, after int) <-chan ResultUseCase {
+	ctxUsecase := "article_usecase_get_sitemap"
+	output := make(chan ResultUseCase)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxUsecase, "recover_usecase_get_sitemap")
+				output <- ResultUseCase{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		if after == model.SitemapRoot {
+			res := <-u.articleRepo.GetSitemapIndex(ctx, model.SitemapMaxURLs)
+			if res.Error != nil {
+				utils.Log(log.ErrorLevel, res.Error.Error(), ctxUsecase, "res_repo_get_sitemap_index")
+				output <- ResultUseCase{Error: res.Error}
+				return
+			}
+
+			index := res.Result.([]model.SitemapPage)
+			if len(index) > 1 {
+				output <- ResultUseCase{Result: model.Sitemap{Index: index}}
+				return
+			}
+			after = 0
+		}
+
+		res := <-u.articleRepo.GetSitemapEntries(ctx, after, model.SitemapMaxURLs)
+		if res.Error != nil {
+			utils.Log(log.ErrorLevel, res.Error.Error(), ctxUsecase, "res_repo_get_sitemap_entries")
+			output <- ResultUseCase{Error: res.Error}
+			return
+		}
+
+		// only the first page exists when there is no published article
+		entries := res.Result.([]model.SitemapEntry)
+		if len(entries) == 0 && after > 0 {
+			output <- ResultUseCase{Error: shared.ErrDataNotFound}
+			return
+		}
+
+		output <- ResultUseCase{Result: model.Sitemap{Entries: entries}}
+	}()
+
+	return output
+}
@@ -0,0 +1,215 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/willy182/boilerplate-go-cleanarch/src/articles/v1/model"
+	"github.com/willy182/boilerplate-go-cleanarch/src/shared"
+	"github.com/willy182/boilerplate-go-cleanarch/utils"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// AttachTags use case handler for attaching tags into article by their name
+func (u *articleUseCase) AttachTags(ctx context.Context, ID int, param model.TaxonomyRequest) <-chan ResultUseCase {
+	ctxUsecase := "article_usecase_attach_tags"
+
+	return u.changeTaxonomy(ctx, ctxUsecase, ID, func() error {
+		tags, err := buildTerms("tag", param.Names)
+		if err != nil {
+			return err
+		}
+		return <-u.articleRepo.AttachTags(ctx, ID, tags)
+	})
+}
+
+// DetachTag use case handler for detaching tag from article by the tag slug
+func (u *articleUseCase) DetachTag(ctx context.Context, ID int, slug string) <-chan ResultUseCase {
+	ctxUsecase := "article_usecase_detach_tag"
+
+	return u.changeTaxonomy(ctx, ctxUsecase, ID, func() error {
+		return <-u.articleRepo.DetachTag(ctx, ID, slug)
+	})
+}
+
+// AttachCategories use case handler for attaching categories into article by their name
+func (u *articleUseCase) AttachCategories(ctx context.Context, ID int, param model.TaxonomyRequest) <-chan ResultUseCase {
+	ctxUsecase := "article_usecase_attach_categories"
+
+	return u.changeTaxonomy(ctx, ctxUsecase, ID, func() error {
+		categories, err := buildTerms("category", param.Names)
+		if err != nil {
+			return err
+		}
+		return <-u.articleRepo.AttachCategories(ctx, ID, categories)
+	})
+}
+
+// DetachCategory use case handler for detaching category from article by the category slug
+func (u *articleUseCase) DetachCategory(ctx context.Context, ID int, slug string) <-chan ResultUseCase {
+	ctxUsecase := "article_usecase_detach_category"
+
+	return u.changeTaxonomy(ctx, ctxUsecase, ID, func() error {
+		return <-u.articleRepo.DetachCategory(ctx, ID, slug)
+	})
+}
+
+// GetTags use case handler for get tags with total articles caller can see
+func (u *articleUseCase) GetTags(ctx context.Context) <-chan ResultUseCase {
+	ctxUsecase := "article_usecase_get_tags"
+	output := make(chan ResultUseCase)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxUsecase, "recover_usecase_get_tags")
+				output <- ResultUseCase{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		params := visibleParams(ctx, model.ArticleParams{})
+
+		res := <-u.articleRepo.GetTagCounts(ctx, params.Status)
+		if res.Error != nil {
+			utils.Log(log.ErrorLevel, res.Error.Error(), ctxUsecase, "res_repo_get_tag_counts")
+			output <- ResultUseCase{Error: res.Error}
+			return
+		}
+
+		output <- ResultUseCase{Result: res.Result.([]model.TagCount)}
+	}()
+
+	return output
+}
+
+// GetCategories use case handler for get categories with total articles caller can see
+func (u *articleUseCase) GetCategories(ctx context.Context) <-chan ResultUseCase {
+	ctxUsecase := "article_usecase_get_categories"
+	output := make(chan ResultUseCase)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxUsecase, "recover_usecase_get_categories")
+				output <- ResultUseCase{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		params := visibleParams(ctx, model.ArticleParams{})
+
+		res := <-u.articleRepo.GetCategoryCounts(ctx, params.Status)
+		if res.Error != nil {
+			utils.Log(log.ErrorLevel, res.Error.Error(), ctxUsecase, "res_repo_get_category_counts")
+			output <- ResultUseCase{Error: res.Error}
+			return
+		}
+
+		output <- ResultUseCase{Result: res.Result.([]model.CategoryCount)}
+	}()
+
+	return output
+}
+
+// changeTaxonomy function for running change of article tags or categories by privileged caller,
+// the changed article is returned with its tags and categories
+func (u *articleUseCase) changeTaxonomy(ctx context.Context, ctxUsecase string, ID int, change func() error) <-chan ResultUseCase {
+	output := make(chan ResultUseCase)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxUsecase, "recover_usecase_change_taxonomy")
+				output <- ResultUseCase{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		if !shared.CallerFromContext(ctx).IsPrivileged() {
+			output <- ResultUseCase{Error: shared.ErrForbidden}
+			return
+		}
+
+		if err := change(); err != nil {
+			utils.Log(log.ErrorLevel, err.Error(), ctxUsecase, "res_repo_change_taxonomy")
+			output <- ResultUseCase{Error: err}
+			return
+		}
+
+		res := <-u.articleRepo.GetByID(ctx, ID)
+		if res.Error != nil {
+			utils.Log(log.ErrorLevel, res.Error.Error(), ctxUsecase, "res_repo_get_by_id")
+			output <- ResultUseCase{Error: res.Error}
+			return
+		}
+
+		articles := []model.Article{res.Result.(model.Article)}
+		if err := u.loadTaxonomies(ctx, articles); err != nil {
+			utils.Log(log.ErrorLevel, err.Error(), ctxUsecase, "load_taxonomies")
+			output <- ResultUseCase{Error: err}
+			return
+		}
+
+		output <- ResultUseCase{Result: articles[0]}
+	}()
+
+	return output
+}
+
+// loadTaxonomies function for filling tags and categories of articles
+func (u *articleUseCase) loadTaxonomies(ctx context.Context, articles []model.Article) error {
+	ids := make([]int, 0, len(articles))
+	for _, article := range articles {
+		ids = append(ids, article.ID)
+	}
+
+	tagsChan := u.articleRepo.GetTagsByArticleIDs(ctx, ids)
+	categoriesChan := u.articleRepo.GetCategoriesByArticleIDs(ctx, ids)
+
+	resTags := <-tagsChan
+	resCategories := <-categoriesChan
+
+	if resTags.Error != nil {
+		return resTags.Error
+	}
+
+	if resCategories.Error != nil {
+		return resCategories.Error
+	}
+
+	tags := resTags.Result.(map[int][]model.Tag)
+	categories := resCategories.Result.(map[int][]model.Category)
+	for i := range articles {
+		articles[i].Tags = tags[articles[i].ID]
+		articles[i].Categories = categories[articles[i].ID]
+	}
+
+	return nil
+}
+
+// buildTerms function for converting names into unique tags or categories with their generated slug
+func buildTerms(kind string, names []string) ([]model.Tag, error) {
+	terms := make([]model.Tag, 0, len(names))
+	seen := make(map[string]bool)
+
+	for _, name := range names {
+		slug := shared.Slugify(name)
+		if slug == "" {
+			return nil, fmt.Errorf("%s %q must contain alphanumeric character", kind, name)
+		}
+
+		if seen[slug] {
+			continue
+		}
+		seen[slug] = true
+
+		terms = append(terms, model.Tag{Name: name, Slug: slug})
+	}
+
+	return terms, nil
+}
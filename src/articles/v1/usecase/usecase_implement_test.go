@@ -0,0 +1,288 @@
+package usecase
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/willy182/boilerplate-go-cleanarch/src/articles/v1/model"
+	"github.com/willy182/boilerplate-go-cleanarch/src/articles/v1/repository"
+	"github.com/willy182/boilerplate-go-cleanarch/src/shared"
+)
+
+// stubArticleRepo article repository that only implements the methods used by a test,
+// calling any other method panics
+type stubArticleRepo struct {
+	repository.Repository
+	articles []model.Article
+	saved    *model.GormArticle
+}
+
+func (r *stubArticleRepo) GetByID(ctx context.Context, ID int, fields ...string) <-chan repository.ResultRepository {
+	output := make(chan repository.ResultRepository, 1)
+	output <- repository.ResultRepository{Error: shared.ErrDataNotFound}
+	for _, article := range r.articles {
+		if article.ID == ID {
+			output = make(chan repository.ResultRepository, 1)
+			output <- repository.ResultRepository{Result: article}
+		}
+	}
+	close(output)
+	return output
+}
+
+func (r *stubArticleRepo) Save(ctx context.Context, param *model.GormArticle) <-chan repository.ResultRepository {
+	r.saved = param
+	output := make(chan repository.ResultRepository, 1)
+	output <- repository.ResultRepository{Result: model.Article{ID: param.ID, Title: param.Title}}
+	close(output)
+	return output
+}
+
+// editorContext function for getting context of privileged caller
+func editorContext() context.Context {
+	return shared.NewCallerContext(context.Background(), shared.Caller{Name: "editor", Role: shared.RoleEditor})
+}
+
+func (r *stubArticleRepo) GetAllByCursor(ctx context.Context, params model.ArticleParams) <-chan repository.ResultRepository {
+	output := make(chan repository.ResultRepository, 1)
+	output <- repository.ResultRepository{Result: r.articles}
+	close(output)
+	return output
+}
+
+func TestGetAllByCursorEmptyPage(t *testing.T) {
+	created := time.Date(2020, 5, 1, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		cursor   *model.ArticleCursor
+		wantNext *model.ArticleCursor
+		wantPrev *model.ArticleCursor
+	}{
+		{name: "first page", cursor: nil},
+		{
+			name:     "past the last page",
+			cursor:   &model.ArticleCursor{Created: created, ID: 7},
+			wantPrev: &model.ArticleCursor{Created: created, ID: 6, Prev: true},
+		},
+		{
+			name:     "before the first page",
+			cursor:   &model.ArticleCursor{Created: created, ID: 7, Prev: true},
+			wantNext: &model.ArticleCursor{Created: created, ID: 8},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &articleUseCase{articleRepo: &stubArticleRepo{articles: []model.Article{}}}
+
+			res := <-u.GetAllByCursor(context.Background(), model.ArticleParams{Limit: 10, Cursor: tt.cursor})
+			if res.Error != nil {
+				t.Fatalf("GetAllByCursor() error = %v", res.Error)
+			}
+
+			result := res.Result.(model.ArticleCursorList)
+			if result.Next.Encode() != tt.wantNext.Encode() {
+				t.Errorf("Next = %+v, want %+v", result.Next, tt.wantNext)
+			}
+
+			if result.Prev.Encode() != tt.wantPrev.Encode() {
+				t.Errorf("Prev = %+v, want %+v", result.Prev, tt.wantPrev)
+			}
+		})
+	}
+}
+
+func TestSaveAuthorReplacement(t *testing.T) {
+	existing := model.Article{ID: 3, Title: "title", Summary: "summary", Slug: "title", AuthorID: 9, Version: 2}
+	title := "new title"
+
+	tests := []struct {
+		name       string
+		save       func(u *articleUseCase) ResultUseCase
+		wantAuthor *int
+	}{
+		{
+			name: "update without author clears it",
+			save: func(u *articleUseCase) ResultUseCase {
+				return <-u.Update(editorContext(), 3, model.ArticleRequest{Title: title, Summary: "summary", Version: 2})
+			},
+		},
+		{
+			name: "patch without author keeps it",
+			save: func(u *articleUseCase) ResultUseCase {
+				return <-u.Patch(editorContext(), 3, model.ArticlePatchRequest{Title: &title, Version: 2})
+			},
+			wantAuthor: &existing.AuthorID,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &stubArticleRepo{articles: []model.Article{existing}}
+			u := &articleUseCase{articleRepo: repo}
+
+			if res := tt.save(u); res.Error != nil {
+				t.Fatalf("save error = %v", res.Error)
+			}
+
+			got := repo.saved.AuthorID
+			if (got == nil) != (tt.wantAuthor == nil) || (got != nil && *got != *tt.wantAuthor) {
+				t.Errorf("AuthorID = %v, want %v", got, tt.wantAuthor)
+			}
+		})
+	}
+}
+
+func TestWriteRequiresRole(t *testing.T) {
+	anonymous := context.Background()
+	editor := editorContext()
+
+	tests := []struct {
+		name  string
+		write func(u *articleUseCase, ctx context.Context) error
+	}{
+		{name: "create", write: func(u *articleUseCase, ctx context.Context) error {
+			return (<-u.Create(ctx, model.ArticleRequest{Title: "title", Summary: "summary"})).Error
+		}},
+		{name: "update", write: func(u *articleUseCase, ctx context.Context) error {
+			return (<-u.Update(ctx, 3, model.ArticleRequest{Title: "title", Summary: "summary", Version: 1})).Error
+		}},
+		{name: "patch", write: func(u *articleUseCase, ctx context.Context) error {
+			return (<-u.Patch(ctx, 3, model.ArticlePatchRequest{Version: 1})).Error
+		}},
+		{name: "delete", write: func(u *articleUseCase, ctx context.Context) error {
+			return <-u.Delete(ctx, 3)
+		}},
+		{name: "restore", write: func(u *articleUseCase, ctx context.Context) error {
+			return (<-u.Restore(ctx, 3)).Error
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &articleUseCase{articleRepo: &stubArticleRepo{}}
+			if err := tt.write(u, anonymous); err != shared.ErrForbidden {
+				t.Errorf("anonymous caller error = %v, want %v", err, shared.ErrForbidden)
+			}
+		})
+	}
+
+	// deleting and restoring is limited to admin like listing deleted articles
+	u := &articleUseCase{articleRepo: &stubArticleRepo{}}
+	if err := <-u.Delete(editor, 3); err != shared.ErrForbidden {
+		t.Errorf("editor delete error = %v, want %v", err, shared.ErrForbidden)
+	}
+
+	if err := (<-u.Restore(editor, 3)).Error; err != shared.ErrForbidden {
+		t.Errorf("editor restore error = %v, want %v", err, shared.ErrForbidden)
+	}
+
+	// batch delete is limited to admin the same as Delete, the atomic batch applies nothing
+	res := <-u.Batch(editor, model.ArticleBatchRequest{Operations: []model.ArticleBatchOperation{{Action: model.BatchDelete, ID: 3}}})
+	if res.Error != nil {
+		t.Fatalf("editor batch error = %v", res.Error)
+	}
+
+	if results := res.Result.([]model.ArticleBatchResult); results[0].Err != shared.ErrForbidden {
+		t.Errorf("editor batch delete error = %v, want %v", results[0].Err, shared.ErrForbidden)
+	}
+}
+
+// batchArticleRepo article repository that keeps applied batch items, slugs in used are taken by saved articles,
+// every item fails with err when it is set
+type batchArticleRepo struct {
+	stubArticleRepo
+	used  map[string]bool
+	items []model.ArticleBatchItem
+	err   error
+}
+
+func (r *batchArticleRepo) GetAvailableSlug(ctx context.Context, base string, articleID int) <-chan repository.ResultRepository {
+	slug := base
+	for i := 2; r.used[slug]; i++ {
+		slug = base + "-" + strconv.Itoa(i)
+	}
+
+	output := make(chan repository.ResultRepository, 1)
+	output <- repository.ResultRepository{Result: slug}
+	close(output)
+	return output
+}
+
+func (r *batchArticleRepo) Batch(ctx context.Context, items []model.ArticleBatchItem, atomic bool) <-chan repository.ResultRepository {
+	r.items = items
+	results := make([]model.ArticleBatchResult, 0, len(items))
+	for _, item := range items {
+		results = append(results, model.ArticleBatchResult{Index: item.Index, Action: item.Action, Applied: r.err == nil, Err: r.err})
+	}
+
+	output := make(chan repository.ResultRepository, 1)
+	output <- repository.ResultRepository{Result: results}
+	close(output)
+	return output
+}
+
+func TestBatchCreateSameTitle(t *testing.T) {
+	tests := []struct {
+		name     string
+		used     map[string]bool
+		slugs    []string
+		expected []string
+	}{
+		{"free title", nil, []string{"", "", ""}, []string{"news", "news-2", "news-3"}},
+		{"suffix taken by saved article", map[string]bool{"news": true, "news-3": true}, []string{"", ""}, []string{"news-2", "news-4"}},
+		{"generated after explicit", nil, []string{"news", ""}, []string{"news", "news-2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &batchArticleRepo{used: tt.used}
+			u := &articleUseCase{articleRepo: repo}
+
+			var operations []model.ArticleBatchOperation
+			for _, slug := range tt.slugs {
+				operations = append(operations, model.ArticleBatchOperation{
+					Action:  model.BatchCreate,
+					Article: &model.ArticleRequest{Title: "News", Summary: "summary", Slug: slug},
+				})
+			}
+
+			res := <-u.Batch(editorContext(), model.ArticleBatchRequest{Operations: operations})
+			if res.Error != nil {
+				t.Fatalf("batch error = %v", res.Error)
+			}
+
+			for _, result := range res.Result.([]model.ArticleBatchResult) {
+				if result.Err != nil {
+					t.Fatalf("operation %d error = %v", result.Index, result.Err)
+				}
+			}
+
+			if len(repo.items) != len(tt.expected) {
+				t.Fatalf("applied %d items, expected %d", len(repo.items), len(tt.expected))
+			}
+			for i, item := range repo.items {
+				if item.Article.Slug != tt.expected[i] {
+					t.Errorf("slug of item %d = %q, expected %q", i, item.Article.Slug, tt.expected[i])
+				}
+			}
+		})
+	}
+}
+
+func TestBatchCreateSameExplicitSlug(t *testing.T) {
+	u := &articleUseCase{articleRepo: &batchArticleRepo{}}
+
+	article := &model.ArticleRequest{Title: "News", Summary: "summary", Slug: "news"}
+	res := <-u.Batch(editorContext(), model.ArticleBatchRequest{Operations: []model.ArticleBatchOperation{
+		{Action: model.BatchCreate, Article: article},
+		{Action: model.BatchCreate, Article: article},
+	}})
+
+	if results := res.Result.([]model.ArticleBatchResult); results[1].Err != model.ErrSlugUsed {
+		t.Errorf("second explicit slug error = %v, want %v", results[1].Err, model.ErrSlugUsed)
+	}
+}
@@ -0,0 +1,198 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/willy182/boilerplate-go-cleanarch/src/articles/v1/model"
+	"github.com/willy182/boilerplate-go-cleanarch/src/shared"
+	"github.com/willy182/boilerplate-go-cleanarch/utils"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Import use case handler for creating articles from rows as they are received, only admin can import,
+// failed row never stops the import and is reported by its position, dry run validates every row without saving it
+func (u *articleUseCase) Import(ctx context.Context, rows <-chan model.ArticleImportRow, dryRun bool) <-chan ResultUseCase {
+	ctxUsecase := "article_usecase_import"
+	output := make(chan ResultUseCase)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxUsecase, "recover_usecase_import")
+				output <- ResultUseCase{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		if !shared.CallerFromContext(ctx).IsAdmin() {
+			output <- ResultUseCase{Error: shared.ErrForbidden}
+			return
+		}
+
+		result := model.ArticleImportResult{DryRun: dryRun, Errors: shared.NewMultiError()}
+
+		// slugs taken by previous rows, dry run has nothing saved for GetAvailableSlug to see
+		slugs := make(map[string]bool)
+		for row := range rows {
+			if result.Total == model.ImportMaxRows {
+				result.Errors.Append("rows", fmt.Errorf("import is limited to %d rows, the rest is skipped", model.ImportMaxRows))
+				break
+			}
+			result.Total++
+
+			if err := u.importRow(ctx, row, dryRun, slugs); err != nil {
+				utils.Log(log.ErrorLevel, err.Error(), ctxUsecase, "import_row")
+				result.Failed++
+				result.Errors.Append(fmt.Sprintf("row %d", row.Row), err)
+				continue
+			}
+			result.Imported++
+		}
+
+		output <- ResultUseCase{Result: result}
+	}()
+
+	return output
+}
+
+// importRow function for validating single import row and saving it with its tags and categories in one transaction unless dryRun,
+// the row keeps its status, published article without publish time is published now
+func (u *articleUseCase) importRow(ctx context.Context, row model.ArticleImportRow, dryRun bool, slugs map[string]bool) error {
+	if row.Err != nil {
+		return row.Err
+	}
+
+	if err := u.validateAuthor(ctx, row.AuthorID); err != nil {
+		return err
+	}
+
+	tags, err := buildTerms("tag", termNames(row.Tags))
+	if err != nil {
+		return err
+	}
+
+	categories, err := buildTerms("category", termNames(row.Categories))
+	if err != nil {
+		return err
+	}
+
+	if row.Slug != "" && slugs[row.Slug] {
+		return model.ErrSlugUsed
+	}
+
+	slug, err := u.resolveSlug(ctx, 0, row.Slug, row.Title)
+	if err != nil {
+		return err
+	}
+	slugs[slug] = true
+
+	if dryRun {
+		return nil
+	}
+
+	now := time.Now()
+	article := &model.GormArticle{
+		Title:       row.Title,
+		Summary:     row.Summary,
+		Description: row.Description,
+		Image:       row.Image,
+		Slug:        slug,
+		AuthorID:    optionalID(row.AuthorID),
+		Created:     row.Created,
+		Status:      row.Status,
+		PublishedAt: row.PublishedAt,
+	}
+
+	if article.Created == nil {
+		article.Created = &now
+	}
+
+	if article.Status == "" {
+		article.Status = model.StatusDraft
+	}
+
+	if article.Status == model.StatusPublished && article.PublishedAt == nil {
+		article.PublishedAt = &now
+	}
+
+	// article is created with its tags and categories as single atomic batch item, so failed row leaves nothing behind
+	item := model.ArticleBatchItem{Action: model.BatchCreate, Article: article, Tags: tags, Categories: categories}
+	res := <-u.articleRepo.Batch(ctx, []model.ArticleBatchItem{item}, true)
+	if res.Error != nil {
+		return res.Error
+	}
+
+	return res.Result.([]model.ArticleBatchResult)[0].Err
+}
+
+// Export use case handler for streaming articles that caller can see one article per result with their tags and categories,
+// taxonomies are loaded for model.ExportBatchSize articles at once, streaming is stopped by the first error or when ctx is done
+func (u *articleUseCase) Export(ctx context.Context, params model.ArticleParams) <-chan ResultUseCase {
+	ctxUsecase := "article_usecase_export"
+	output := make(chan ResultUseCase, model.ExportBatchSize)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxUsecase, "recover_usecase_export")
+				output <- ResultUseCase{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		batch := make([]model.Article, 0, model.ExportBatchSize)
+		flush := func() bool {
+			if len(batch) == 0 {
+				return true
+			}
+
+			if err := u.loadTaxonomies(ctx, batch); err != nil {
+				utils.Log(log.ErrorLevel, err.Error(), ctxUsecase, "load_taxonomies")
+				output <- ResultUseCase{Error: err}
+				return false
+			}
+
+			for _, article := range batch {
+				select {
+				case output <- ResultUseCase{Result: article}:
+				case <-ctx.Done():
+					return false
+				}
+			}
+
+			batch = batch[:0]
+			return true
+		}
+
+		for res := range u.articleRepo.Export(ctx, visibleParams(ctx, params)) {
+			if res.Error != nil {
+				utils.Log(log.ErrorLevel, res.Error.Error(), ctxUsecase, "res_repo_export")
+				output <- ResultUseCase{Error: res.Error}
+				return
+			}
+
+			batch = append(batch, res.Result.(model.Article))
+			if len(batch) == model.ExportBatchSize && !flush() {
+				return
+			}
+		}
+
+		flush()
+	}()
+
+	return output
+}
+
+// termNames function for getting names of tags or categories
+func termNames(terms []model.Tag) []string {
+	names := make([]string, 0, len(terms))
+	for _, term := range terms {
+		names = append(names, term.Name)
+	}
+	return names
+}
@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/willy182/boilerplate-go-cleanarch/src/articles/v1/model"
+	"github.com/willy182/boilerplate-go-cleanarch/src/shared"
+)
+
+func TestImportRowWithTerms(t *testing.T) {
+	errTerm := errors.New("term failed")
+
+	tests := []struct {
+		name         string
+		err          error
+		wantImported int
+		wantFailed   int
+	}{
+		{name: "saved with its terms", wantImported: 1},
+		{name: "failed terms roll back the article", err: errTerm, wantFailed: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &batchArticleRepo{err: tt.err}
+			u := &articleUseCase{articleRepo: repo}
+
+			rows := make(chan model.ArticleImportRow, 1)
+			rows <- model.ArticleImportRow{Row: 1, Title: "News", Summary: "summary",
+				Tags: []model.Tag{{Name: "Go"}}, Categories: []model.Category{{Name: "Tech"}}}
+			close(rows)
+
+			ctx := shared.NewCallerContext(context.Background(), shared.Caller{Name: "admin", Role: shared.RoleAdmin})
+			res := <-u.Import(ctx, rows, false)
+			if res.Error != nil {
+				t.Fatalf("import error = %v", res.Error)
+			}
+
+			result := res.Result.(model.ArticleImportResult)
+			if result.Imported != tt.wantImported || result.Failed != tt.wantFailed {
+				t.Errorf("imported = %d, failed = %d, want %d and %d", result.Imported, result.Failed, tt.wantImported, tt.wantFailed)
+			}
+
+			if len(repo.items) != 1 {
+				t.Fatalf("applied %d batch items, expected 1", len(repo.items))
+			}
+
+			item := repo.items[0]
+			if item.Action != model.BatchCreate || len(item.Tags) != 1 || len(item.Categories) != 1 {
+				t.Errorf("batch item = %+v, expected create with its tag and category", item)
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/willy182/boilerplate-go-cleanarch/src/articles/v1/model"
+	"github.com/willy182/boilerplate-go-cleanarch/src/shared"
+	"github.com/willy182/boilerplate-go-cleanarch/utils"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// SaveTranslation use case handler for creating or replacing translation of article in requested locale
+func (u *articleUseCase) SaveTranslation(ctx context.Context, ID int, param model.ArticleTranslationRequest) <-chan ResultUseCase {
+	ctxUsecase := "article_usecase_save_translation"
+	output := make(chan ResultUseCase)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxUsecase, "recover_usecase_save_translation")
+				output <- ResultUseCase{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		if !shared.CallerFromContext(ctx).IsPrivileged() {
+			output <- ResultUseCase{Error: shared.ErrForbidden}
+			return
+		}
+
+		resArticle := <-u.articleRepo.GetByID(ctx, ID, "id")
+		if resArticle.Error != nil {
+			utils.Log(log.ErrorLevel, resArticle.Error.Error(), ctxUsecase, "res_repo_get_by_id")
+			output <- ResultUseCase{Error: resArticle.Error}
+			return
+		}
+
+		now := time.Now()
+		res := <-u.articleRepo.SaveTranslation(ctx, &model.GormArticleTranslation{
+			ArticleID:   ID,
+			Locale:      param.Locale,
+			Title:       param.Title,
+			Summary:     param.Summary,
+			Description: param.Description,
+			Created:     &now,
+		})
+		if res.Error != nil {
+			utils.Log(log.ErrorLevel, res.Error.Error(), ctxUsecase, "res_repo_save_translation")
+			output <- ResultUseCase{Error: res.Error}
+			return
+		}
+
+		output <- ResultUseCase{Result: res.Result.(model.ArticleTranslation)}
+	}()
+
+	return output
+}
+
+// GetTranslations use case handler for get every translation of article, translations follow visibility of their article
+func (u *articleUseCase) GetTranslations(ctx context.Context, ID int) <-chan ResultUseCase {
+	ctxUsecase := "article_usecase_get_translations"
+	output := make(chan ResultUseCase)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxUsecase, "recover_usecase_get_translations")
+				output <- ResultUseCase{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		articleChan := u.GetByID(ctx, ID, "id")
+		translationsChan := u.articleRepo.GetTranslations(ctx, ID)
+
+		resArticle := <-articleChan
+		resTranslations := <-translationsChan
+
+		if resArticle.Error != nil {
+			utils.Log(log.ErrorLevel, resArticle.Error.Error(), ctxUsecase, "res_get_by_id")
+			output <- ResultUseCase{Error: resArticle.Error}
+			return
+		}
+
+		if resTranslations.Error != nil {
+			utils.Log(log.ErrorLevel, resTranslations.Error.Error(), ctxUsecase, "res_repo_get_translations")
+			output <- ResultUseCase{Error: resTranslations.Error}
+			return
+		}
+
+		output <- ResultUseCase{Result: resTranslations.Result.([]model.ArticleTranslation)}
+	}()
+
+	return output
+}
+
+// Translate use case handler for replacing content of articles with their translation in the first available locale,
+// locales is fallback chain in order of preference, article without translation in any of them keeps model.DefaultLocale
+func (u *articleUseCase) Translate(ctx context.Context, articles []model.Article, locales []string) <-chan ResultUseCase {
+	ctxUsecase := "article_usecase_translate"
+	output := make(chan ResultUseCase)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxUsecase, "recover_usecase_translate")
+				output <- ResultUseCase{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		// content of default locale is on the article itself, locales after it in the chain are never reached
+		wanted := make([]string, 0, len(locales))
+		for _, locale := range locales {
+			if locale == model.DefaultLocale {
+				break
+			}
+			wanted = append(wanted, locale)
+		}
+
+		translations := make(map[int][]model.ArticleTranslation)
+		if len(wanted) > 0 && len(articles) > 0 {
+			ids := make([]int, 0, len(articles))
+			for _, article := range articles {
+				ids = append(ids, article.ID)
+			}
+
+			res := <-u.articleRepo.GetTranslationsByArticleIDs(ctx, ids, wanted)
+			if res.Error != nil {
+				utils.Log(log.ErrorLevel, res.Error.Error(), ctxUsecase, "res_repo_get_translations_by_article_ids")
+				output <- ResultUseCase{Error: res.Error}
+				return
+			}
+			translations = res.Result.(map[int][]model.ArticleTranslation)
+		}
+
+		for i := range articles {
+			articles[i].Locale = model.DefaultLocale
+			if translation, ok := preferredTranslation(translations[articles[i].ID], wanted); ok {
+				articles[i].Translate(translation)
+			}
+		}
+
+		output <- ResultUseCase{Result: articles}
+	}()
+
+	return output
+}
+
+// preferredTranslation function for picking translation of the first locale that is available
+func preferredTranslation(translations []model.ArticleTranslation, locales []string) (model.ArticleTranslation, bool) {
+	for _, locale := range locales {
+		for _, translation := range translations {
+			if translation.Locale == locale {
+				return translation, true
+			}
+		}
+	}
+	return model.ArticleTranslation{}, false
+}
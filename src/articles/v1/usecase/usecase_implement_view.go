@@ -0,0 +1,142 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/willy182/boilerplate-go-cleanarch/src/articles/v1/model"
+	"github.com/willy182/boilerplate-go-cleanarch/src/shared"
+	"github.com/willy182/boilerplate-go-cleanarch/utils"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// viewBuffer in memory counter of article views per day that are not written yet,
+// flushing is set while a flush triggered by full buffer is running
+type viewBuffer struct {
+	mu       sync.Mutex
+	counts   map[viewKey]int64
+	flushing int32
+}
+
+// viewKey data of struct for article day that views are counted in
+type viewKey struct {
+	articleID int
+	day       string
+}
+
+// newViewBuffer function for creating empty view buffer
+func newViewBuffer() *viewBuffer {
+	return &viewBuffer{counts: make(map[viewKey]int64)}
+}
+
+// add function for counting views of article in day, the number of buffered article days is returned
+func (b *viewBuffer) add(articleID int, day string, views int64) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.counts[viewKey{articleID: articleID, day: day}] += views
+	return len(b.counts)
+}
+
+// take function for getting every buffered views and emptying the buffer
+func (b *viewBuffer) take() []model.ArticleView {
+	b.mu.Lock()
+	counts := b.counts
+	b.counts = make(map[viewKey]int64)
+	b.mu.Unlock()
+
+	views := make([]model.ArticleView, 0, len(counts))
+	for key, count := range counts {
+		views = append(views, model.ArticleView{ArticleID: key.articleID, Day: key.day, Views: count})
+	}
+	return views
+}
+
+// RecordView use case handler for counting view of article without waiting for it to be written,
+// only published article read by public caller is counted, buffer is flushed early when it is full
+// and no early flush is running yet so burst of views does not start a flush per view
+func (u *articleUseCase) RecordView(ctx context.Context, article model.Article) {
+	if article.Status != model.StatusPublished || shared.CallerFromContext(ctx).IsPrivileged() {
+		return
+	}
+
+	full := u.views.add(article.ID, time.Now().Format(model.ViewDayFormat), 1) >= model.ViewBufferSize
+	if full && atomic.CompareAndSwapInt32(&u.views.flushing, 0, 1) {
+		go func() {
+			defer atomic.StoreInt32(&u.views.flushing, 0)
+			<-u.FlushViews(context.Background())
+		}()
+	}
+}
+
+// FlushViews use case handler for writing buffered views into daily counters in batches of model.ViewBufferSize,
+// views that can not be written are put back into the buffer for the next flush
+func (u *articleUseCase) FlushViews(ctx context.Context) <-chan ResultUseCase {
+	ctxUsecase := "article_usecase_flush_views"
+	output := make(chan ResultUseCase)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxUsecase, "recover_usecase_flush_views")
+				output <- ResultUseCase{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		views := u.views.take()
+		for written := 0; written < len(views); written += model.ViewBufferSize {
+			end := written + model.ViewBufferSize
+			if end > len(views) {
+				end = len(views)
+			}
+
+			if err := <-u.articleRepo.IncrementViews(ctx, views[written:end]); err != nil {
+				utils.Log(log.ErrorLevel, err.Error(), ctxUsecase, "res_repo_increment_views")
+				for _, view := range views[written:] {
+					u.views.add(view.ArticleID, view.Day, view.Views)
+				}
+				output <- ResultUseCase{Error: err}
+				return
+			}
+		}
+
+		output <- ResultUseCase{Result: len(views)}
+	}()
+
+	return output
+}
+
+// GetPopular use case handler for get published articles with the most views in window of params
+func (u *articleUseCase) GetPopular(ctx context.Context, params model.PopularParams) <-chan ResultUseCase {
+	ctxUsecase := "article_usecase_get_popular"
+	output := make(chan ResultUseCase)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxUsecase, "recover_usecase_get_popular")
+				output <- ResultUseCase{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		since := time.Now().Add(-model.PopularWindows[params.Window]).Format(model.ViewDayFormat)
+		res := <-u.articleRepo.GetPopular(ctx, since, params.Limit)
+		if res.Error != nil {
+			utils.Log(log.ErrorLevel, res.Error.Error(), ctxUsecase, "res_repo_get_popular")
+			output <- ResultUseCase{Error: res.Error}
+			return
+		}
+
+		output <- ResultUseCase{Result: res.Result.([]model.PopularArticle)}
+	}()
+
+	return output
+}
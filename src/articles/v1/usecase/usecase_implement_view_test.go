@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/willy182/boilerplate-go-cleanarch/src/articles/v1/model"
+	"github.com/willy182/boilerplate-go-cleanarch/src/articles/v1/repository"
+)
+
+// viewArticleRepo article repository whose IncrementViews blocks until release is closed
+type viewArticleRepo struct {
+	repository.Repository
+	calls   int32
+	release chan struct{}
+}
+
+func (r *viewArticleRepo) IncrementViews(ctx context.Context, views []model.ArticleView) <-chan error {
+	atomic.AddInt32(&r.calls, 1)
+	<-r.release
+
+	output := make(chan error, 1)
+	output <- nil
+	close(output)
+	return output
+}
+
+func TestRecordViewSingleEarlyFlush(t *testing.T) {
+	repo := &viewArticleRepo{release: make(chan struct{})}
+	u := &articleUseCase{articleRepo: repo, views: newViewBuffer()}
+
+	// buffer is full from the last article on, every later view would start its own flush without the guard
+	for id := 1; id <= model.ViewBufferSize+100; id++ {
+		u.RecordView(context.Background(), model.Article{ID: id, Status: model.StatusPublished})
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for atomic.LoadInt32(&repo.calls) == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(20 * time.Millisecond)
+
+	if calls := atomic.LoadInt32(&repo.calls); calls != 1 {
+		t.Errorf("IncrementViews is called %d times while flush is running, expected 1", calls)
+	}
+
+	close(repo.release)
+	for atomic.LoadInt32(&u.views.flushing) == 1 && time.Now().Before(deadline.Add(time.Second)) {
+		time.Sleep(time.Millisecond)
+	}
+
+	if atomic.LoadInt32(&u.views.flushing) != 0 {
+		t.Errorf("flushing is still set after flush is done")
+	}
+}
+
+func TestRecordViewSkipped(t *testing.T) {
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		article model.Article
+	}{
+		{"draft", context.Background(), model.Article{ID: 1, Status: model.StatusDraft}},
+		{"privileged caller", editorContext(), model.Article{ID: 1, Status: model.StatusPublished}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &articleUseCase{views: newViewBuffer()}
+			u.RecordView(tt.ctx, tt.article)
+
+			if views := u.views.take(); len(views) != 0 {
+				t.Errorf("views = %v, expected none", views)
+			}
+		})
+	}
+}
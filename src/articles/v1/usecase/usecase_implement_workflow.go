@@ -0,0 +1,199 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/willy182/boilerplate-go-cleanarch/src/articles/v1/model"
+	"github.com/willy182/boilerplate-go-cleanarch/src/shared"
+	"github.com/willy182/boilerplate-go-cleanarch/utils"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// articleTransitions allowed status transition of article, mapping from status into next status and roles that can trigger it
+var articleTransitions = map[string]map[string][]string{
+	model.StatusDraft: {
+		model.StatusInReview: {shared.RoleEditor, shared.RoleReviewer, shared.RoleAdmin},
+	},
+	model.StatusInReview: {
+		model.StatusDraft:     {shared.RoleEditor, shared.RoleReviewer, shared.RoleAdmin},
+		model.StatusPublished: {shared.RoleReviewer, shared.RoleAdmin},
+	},
+	model.StatusPublished: {
+		model.StatusArchived: {shared.RoleReviewer, shared.RoleAdmin},
+		model.StatusDraft:    {shared.RoleAdmin},
+	},
+	model.StatusArchived: {
+		model.StatusDraft: {shared.RoleAdmin},
+	},
+}
+
+// ChangeStatus use case handler for moving article into next status of editorial workflow
+func (u *articleUseCase) ChangeStatus(ctx context.Context, ID int, status string, version int) <-chan ResultUseCase {
+	ctxUsecase := "article_usecase_change_status"
+	output := make(chan ResultUseCase)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxUsecase, "recover_usecase_change_status")
+				output <- ResultUseCase{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		caller := shared.CallerFromContext(ctx)
+		if !caller.IsPrivileged() {
+			output <- ResultUseCase{Error: shared.ErrForbidden}
+			return
+		}
+
+		res := <-u.articleRepo.GetByID(ctx, ID)
+		if res.Error != nil {
+			utils.Log(log.ErrorLevel, res.Error.Error(), ctxUsecase, "res_repo_get_by_id")
+			output <- ResultUseCase{Error: res.Error}
+			return
+		}
+
+		existing := res.Result.(model.Article)
+		if err := checkTransition(caller, existing.Status, status); err != nil {
+			output <- ResultUseCase{Error: err}
+			return
+		}
+
+		resUpdate := <-u.articleRepo.UpdateStatus(ctx, ID, status, version)
+		if resUpdate.Error != nil {
+			utils.Log(log.ErrorLevel, resUpdate.Error.Error(), ctxUsecase, "res_repo_update_status")
+			output <- ResultUseCase{Error: resUpdate.Error}
+			return
+		}
+
+		output <- ResultUseCase{Result: resUpdate.Result.(model.Article)}
+	}()
+
+	return output
+}
+
+// checkTransition function for checking that caller can move article from status into next status
+func checkTransition(caller shared.Caller, from, to string) error {
+	roles, ok := articleTransitions[from][to]
+	if !ok {
+		return fmt.Errorf("%w: %s to %s", model.ErrInvalidTransition, from, to)
+	}
+
+	if !shared.StringInSlice(caller.Role, roles) {
+		return shared.ErrForbidden
+	}
+
+	return nil
+}
+
+// Schedule use case handler for set publishing schedule of article,
+// scheduled publishing and unpublishing must be transitions the caller can trigger from the current status,
+// so only article in review can be scheduled for publishing, clearing the schedule needs the role that can publish
+func (u *articleUseCase) Schedule(ctx context.Context, ID int, param model.ArticleScheduleRequest) <-chan ResultUseCase {
+	ctxUsecase := "article_usecase_schedule"
+	output := make(chan ResultUseCase)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxUsecase, "recover_usecase_schedule")
+				output <- ResultUseCase{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		caller := shared.CallerFromContext(ctx)
+		roles := articleTransitions[model.StatusInReview][model.StatusPublished]
+		if !shared.StringInSlice(caller.Role, roles) {
+			output <- ResultUseCase{Error: shared.ErrForbidden}
+			return
+		}
+
+		if param.PublishAt != nil || param.UnpublishAt != nil {
+			resArticle := <-u.articleRepo.GetByID(ctx, ID)
+			if resArticle.Error != nil {
+				utils.Log(log.ErrorLevel, resArticle.Error.Error(), ctxUsecase, "res_repo_get_by_id")
+				output <- ResultUseCase{Error: resArticle.Error}
+				return
+			}
+
+			if err := checkSchedule(caller, resArticle.Result.(model.Article).Status, param); err != nil {
+				output <- ResultUseCase{Error: err}
+				return
+			}
+		}
+
+		res := <-u.articleRepo.UpdateSchedule(ctx, ID, param.PublishAt, param.UnpublishAt, param.Version)
+		if res.Error != nil {
+			utils.Log(log.ErrorLevel, res.Error.Error(), ctxUsecase, "res_repo_update_schedule")
+			output <- ResultUseCase{Error: res.Error}
+			return
+		}
+
+		output <- ResultUseCase{Result: res.Result.(model.Article)}
+	}()
+
+	return output
+}
+
+// checkSchedule function for checking that caller can trigger the scheduled transitions of article in status,
+// unpublishing of article scheduled for publishing starts from the published status
+func checkSchedule(caller shared.Caller, status string, param model.ArticleScheduleRequest) error {
+	if param.PublishAt != nil {
+		if err := checkTransition(caller, status, model.StatusPublished); err != nil {
+			return err
+		}
+		status = model.StatusPublished
+	}
+
+	if param.UnpublishAt != nil {
+		return checkTransition(caller, status, model.StatusArchived)
+	}
+
+	return nil
+}
+
+// ApplySchedule use case handler for publishing and unpublishing articles whose schedule is due,
+// it keeps applying in batches until there is no more due article
+func (u *articleUseCase) ApplySchedule(ctx context.Context, batchSize int) <-chan ResultUseCase {
+	ctxUsecase := "article_usecase_apply_schedule"
+	output := make(chan ResultUseCase)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxUsecase, "recover_usecase_apply_schedule")
+				output <- ResultUseCase{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		var total model.ScheduleResult
+		for {
+			res := <-u.articleRepo.ApplySchedule(ctx, batchSize)
+			if res.Error != nil {
+				utils.Log(log.ErrorLevel, res.Error.Error(), ctxUsecase, "res_repo_apply_schedule")
+				output <- ResultUseCase{Error: res.Error}
+				return
+			}
+
+			result := res.Result.(model.ScheduleResult)
+			total.Published += result.Published
+			total.Unpublished += result.Unpublished
+
+			if result.Published < batchSize && result.Unpublished < batchSize {
+				break
+			}
+		}
+
+		output <- ResultUseCase{Result: total}
+	}()
+
+	return output
+}
@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/willy182/boilerplate-go-cleanarch/src/articles/v1/model"
+	"github.com/willy182/boilerplate-go-cleanarch/src/shared"
+)
+
+func TestCheckTransition(t *testing.T) {
+	tests := []struct {
+		name    string
+		role    string
+		from    string
+		to      string
+		wantErr error
+	}{
+		{name: "editor submits draft", role: shared.RoleEditor, from: model.StatusDraft, to: model.StatusInReview},
+		{name: "editor returns review into draft", role: shared.RoleEditor, from: model.StatusInReview, to: model.StatusDraft},
+		{name: "editor can not publish", role: shared.RoleEditor, from: model.StatusInReview, to: model.StatusPublished, wantErr: shared.ErrForbidden},
+		{name: "reviewer publishes", role: shared.RoleReviewer, from: model.StatusInReview, to: model.StatusPublished},
+		{name: "reviewer archives", role: shared.RoleReviewer, from: model.StatusPublished, to: model.StatusArchived},
+		{name: "reviewer can not unpublish into draft", role: shared.RoleReviewer, from: model.StatusPublished, to: model.StatusDraft, wantErr: shared.ErrForbidden},
+		{name: "admin unpublishes into draft", role: shared.RoleAdmin, from: model.StatusPublished, to: model.StatusDraft},
+		{name: "admin reopens archived", role: shared.RoleAdmin, from: model.StatusArchived, to: model.StatusDraft},
+		{name: "draft can not skip review", role: shared.RoleAdmin, from: model.StatusDraft, to: model.StatusPublished, wantErr: model.ErrInvalidTransition},
+		{name: "archived can not be published", role: shared.RoleAdmin, from: model.StatusArchived, to: model.StatusPublished, wantErr: model.ErrInvalidTransition},
+		{name: "same status is no transition", role: shared.RoleAdmin, from: model.StatusDraft, to: model.StatusDraft, wantErr: model.ErrInvalidTransition},
+		{name: "anonymous caller", role: "", from: model.StatusDraft, to: model.StatusInReview, wantErr: shared.ErrForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkTransition(shared.Caller{Role: tt.role}, tt.from, tt.to)
+			if !errors.Is(err, tt.wantErr) || (err == nil) != (tt.wantErr == nil) {
+				t.Errorf("checkTransition() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckSchedule(t *testing.T) {
+	at := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name    string
+		role    string
+		status  string
+		param   model.ArticleScheduleRequest
+		wantErr error
+	}{
+		{name: "reviewer schedules review", role: shared.RoleReviewer, status: model.StatusInReview, param: model.ArticleScheduleRequest{PublishAt: &at}},
+		{name: "draft can not be scheduled for publishing", role: shared.RoleAdmin, status: model.StatusDraft, param: model.ArticleScheduleRequest{PublishAt: &at}, wantErr: model.ErrInvalidTransition},
+		{name: "published can not be scheduled for publishing", role: shared.RoleAdmin, status: model.StatusPublished, param: model.ArticleScheduleRequest{PublishAt: &at}, wantErr: model.ErrInvalidTransition},
+		{name: "reviewer schedules unpublishing", role: shared.RoleReviewer, status: model.StatusPublished, param: model.ArticleScheduleRequest{UnpublishAt: &at}},
+		{name: "draft can not be scheduled for unpublishing", role: shared.RoleAdmin, status: model.StatusDraft, param: model.ArticleScheduleRequest{UnpublishAt: &at}, wantErr: model.ErrInvalidTransition},
+		{name: "review is unpublished after its publishing", role: shared.RoleReviewer, status: model.StatusInReview, param: model.ArticleScheduleRequest{PublishAt: &at, UnpublishAt: &at}},
+		{name: "editor can not schedule", role: shared.RoleEditor, status: model.StatusInReview, param: model.ArticleScheduleRequest{PublishAt: &at}, wantErr: shared.ErrForbidden},
+		{name: "cleared schedule", role: shared.RoleReviewer, status: model.StatusDraft},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkSchedule(shared.Caller{Role: tt.role}, tt.status, tt.param)
+			if !errors.Is(err, tt.wantErr) || (err == nil) != (tt.wantErr == nil) {
+				t.Errorf("checkSchedule() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
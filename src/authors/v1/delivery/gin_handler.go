@@ -0,0 +1,190 @@
+package delivery
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/willy182/boilerplate-go-cleanarch/src/authors/v1/model"
+	"github.com/willy182/boilerplate-go-cleanarch/src/authors/v1/usecase"
+	"github.com/willy182/boilerplate-go-cleanarch/src/shared"
+	"github.com/willy182/boilerplate-go-cleanarch/utils"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+)
+
+// AuthorHandler struct for http author handling
+type AuthorHandler struct {
+	AuthorUseCase usecase.UseCase
+}
+
+// NewAuthorHTTPHandler route handler for author
+func NewAuthorHTTPHandler(usecase usecase.UseCase) *AuthorHandler {
+	return &AuthorHandler{AuthorUseCase: usecase}
+}
+
+// Mount function
+func (h *AuthorHandler) Mount(group *gin.RouterGroup) {
+	group.GET("/authors", h.GetAll)
+	group.POST("/author", h.Create)
+	group.GET("/author/:id", h.GetByID)
+	group.PUT("/author/:id", h.Update)
+}
+
+// validateID function for validating id route param, response will be written when id is not valid
+func (h *AuthorHandler) validateID(c *gin.Context, ctxHandler string) (int, bool) {
+	param := c.Param("id")
+	multiError := shared.NewMultiError()
+
+	if ok := shared.ValidateNumeric(param); !ok {
+		multiError.Append("error", fmt.Errorf("id must be numeric"))
+		utils.Log(log.ErrorLevel, multiError.Error(), ctxHandler, "validate_id")
+		response := shared.NewHTTPResponse(http.StatusBadRequest, "validate id", multiError)
+		response.JSON(c.Writer)
+		return 0, false
+	}
+
+	value, _ := strconv.Atoi(param)
+	return value, true
+}
+
+// errorStatusCode function for mapping use case error into http status code
+func errorStatusCode(err error) int {
+	switch {
+	case errors.Is(err, shared.ErrDataNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, model.ErrEmailUsed):
+		return http.StatusConflict
+	case errors.Is(err, shared.ErrForbidden):
+		return http.StatusForbidden
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+// GetByID method for handling route author by ID
+func (h *AuthorHandler) GetByID(c *gin.Context) {
+	ctxHandler := "author_handler_get_by_id"
+	ctx := c.Request.Context()
+	multiError := shared.NewMultiError()
+
+	id, ok := h.validateID(c, ctxHandler)
+	if !ok {
+		return
+	}
+
+	res := <-h.AuthorUseCase.GetByID(ctx, id)
+	if res.Error != nil {
+		utils.Log(log.ErrorLevel, res.Error.Error(), ctxHandler, "err_res_get_by_id")
+		response := shared.NewHTTPResponse(errorStatusCode(res.Error), res.Error.Error(), multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	result := res.Result.(model.Author)
+	meta := shared.CreateMeta(1, 1, 1)
+	response := shared.NewHTTPResponse(http.StatusOK, "Author Get By ID", result, meta)
+	response.JSON(c.Writer)
+}
+
+// GetAll method for handling route author list
+func (h *AuthorHandler) GetAll(c *gin.Context) {
+	ctxHandler := "author_handler_get_all"
+	ctx := c.Request.Context()
+	multiError := shared.NewMultiError()
+
+	var params model.AuthorRequestParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		multiError.Append("error", err)
+		utils.Log(log.ErrorLevel, multiError.Error(), ctxHandler, "bind_params")
+		response := shared.NewHTTPResponse(http.StatusBadRequest, "bind params", multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	authorParams := model.AuthorParams{Query: strings.TrimSpace(params.Query)}
+	authorParams.Page, authorParams.Limit = shared.ParsePagination(params.PageNumber, params.PageSize, multiError)
+	if multiError.HasError() {
+		utils.Log(log.ErrorLevel, multiError.Error(), ctxHandler, "validate_params")
+		response := shared.NewHTTPResponse(http.StatusBadRequest, "validate params", multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	res := <-h.AuthorUseCase.GetAll(ctx, authorParams)
+	if res.Error != nil {
+		utils.Log(log.ErrorLevel, res.Error.Error(), ctxHandler, "err_res_get_all")
+		response := shared.NewHTTPResponse(errorStatusCode(res.Error), res.Error.Error(), multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	result := res.Result.(model.AuthorList)
+	meta := shared.CreateMeta(result.Total, authorParams.Page, authorParams.Limit)
+	response := shared.NewHTTPResponse(http.StatusOK, "Author List", result.Data, meta)
+	response.JSON(c.Writer)
+}
+
+// Create method for handling route create author
+func (h *AuthorHandler) Create(c *gin.Context) {
+	ctxHandler := "author_handler_create"
+	ctx := c.Request.Context()
+	multiError := shared.NewMultiError()
+
+	var param model.AuthorRequest
+	if err := c.ShouldBindJSON(&param); err != nil {
+		multiError.Append("error", err)
+		utils.Log(log.ErrorLevel, multiError.Error(), ctxHandler, "bind_payload")
+		response := shared.NewHTTPResponse(http.StatusBadRequest, "bind payload", multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	res := <-h.AuthorUseCase.Create(ctx, param)
+	if res.Error != nil {
+		utils.Log(log.ErrorLevel, res.Error.Error(), ctxHandler, "err_res_create")
+		response := shared.NewHTTPResponse(errorStatusCode(res.Error), res.Error.Error(), multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	result := res.Result.(model.Author)
+	response := shared.NewHTTPResponse(http.StatusCreated, "Author Created", result)
+	response.JSON(c.Writer)
+}
+
+// Update method for handling route replace author by ID
+func (h *AuthorHandler) Update(c *gin.Context) {
+	ctxHandler := "author_handler_update"
+	ctx := c.Request.Context()
+	multiError := shared.NewMultiError()
+
+	id, ok := h.validateID(c, ctxHandler)
+	if !ok {
+		return
+	}
+
+	var param model.AuthorRequest
+	if err := c.ShouldBindJSON(&param); err != nil {
+		multiError.Append("error", err)
+		utils.Log(log.ErrorLevel, multiError.Error(), ctxHandler, "bind_payload")
+		response := shared.NewHTTPResponse(http.StatusBadRequest, "bind payload", multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	res := <-h.AuthorUseCase.Update(ctx, id, param)
+	if res.Error != nil {
+		utils.Log(log.ErrorLevel, res.Error.Error(), ctxHandler, "err_res_update")
+		response := shared.NewHTTPResponse(errorStatusCode(res.Error), res.Error.Error(), multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	result := res.Result.(model.Author)
+	response := shared.NewHTTPResponse(http.StatusOK, "Author Updated", result)
+	response.JSON(c.Writer)
+}
@@ -0,0 +1,59 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrEmailUsed error when requested email is already used by another author
+var ErrEmailUsed = errors.New("email is already used by another author")
+
+// GormAuthor data of struct
+type GormAuthor struct {
+	ID       int        `gorm:"AUTO_INCREMENT;PRIMARY_KEY"`
+	Name     string     `gorm:"type:varchar(100);NOT NULL"`
+	Email    string     `gorm:"type:varchar(150);NOT NULL;unique_index"`
+	Bio      string     `gorm:"type:text"`
+	Avatar   string     `gorm:"type:varchar(150)"`
+	Created  *time.Time `gorm:"type:timestamp(6) with time zone;NOT NULL"`
+	Modified *time.Time `gorm:"type:timestamp(6) with time zone"`
+}
+
+// Author data of struct
+type Author struct {
+	ID       int       `json:"id"`
+	Name     string    `json:"name"`
+	Email    string    `json:"email,omitempty"`
+	Bio      string    `json:"bio,omitempty"`
+	Avatar   string    `json:"avatar,omitempty"`
+	Created  time.Time `json:"created"`
+	Modified string    `json:"modified,omitempty"`
+}
+
+// AuthorRequest data of struct for create and replace author payload
+type AuthorRequest struct {
+	Name   string `json:"name" binding:"required,max=100"`
+	Email  string `json:"email" binding:"required,email,max=150"`
+	Bio    string `json:"bio"`
+	Avatar string `json:"avatar" binding:"max=150"`
+}
+
+// AuthorRequestParams data of struct for author list query params
+type AuthorRequestParams struct {
+	PageNumber string `form:"page"`
+	PageSize   string `form:"limit"`
+	Query      string `form:"q"`
+}
+
+// AuthorParams data of struct for filtering author list
+type AuthorParams struct {
+	Page  int
+	Limit int
+	Query string
+}
+
+// AuthorList data of struct for author list with its total records
+type AuthorList struct {
+	Data  []Author
+	Total int
+}
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/willy182/boilerplate-go-cleanarch/src/authors/v1/model"
+)
+
+// ResultRepository data structure
+type ResultRepository struct {
+	Result interface{}
+	Error  error
+}
+
+// Repository interface for author repository
+type Repository interface {
+	Save(ctx context.Context, param *model.GormAuthor) <-chan ResultRepository
+	GetByID(ctx context.Context, ID int) <-chan ResultRepository
+	GetByIDs(ctx context.Context, IDs []int) <-chan ResultRepository
+	GetAll(ctx context.Context, params model.AuthorParams) <-chan ResultRepository
+	GetTotal(ctx context.Context, params model.AuthorParams) <-chan ResultRepository
+}
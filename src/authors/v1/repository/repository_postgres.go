@@ -0,0 +1,291 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/willy182/boilerplate-go-cleanarch/src/authors/v1/model"
+	"github.com/willy182/boilerplate-go-cleanarch/src/shared"
+	"github.com/willy182/boilerplate-go-cleanarch/utils"
+
+	"github.com/jinzhu/gorm"
+	"github.com/lib/pq"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	tableName     = "authors"
+	authorColumns = "id, name, email, bio, avatar, created, modified"
+
+	// pqUniqueViolation postgres error code of unique constraint violation
+	pqUniqueViolation = "23505"
+)
+
+// likeEscaper escape wildcard character of LIKE pattern
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// rowScanner abstraction of *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// postgresAuthorRepo struct
+type postgresAuthorRepo struct {
+	read  *gorm.DB
+	write *gorm.DB
+}
+
+// NewPostgresAuthorRepository author repository postgres handler
+func NewPostgresAuthorRepository(read, write *gorm.DB) Repository {
+	return &postgresAuthorRepo{
+		read:  read,
+		write: write,
+	}
+}
+
+// Save function, for insert new author when its id is zero, otherwise update existing author,
+// the persisted row is returned as model.Author
+func (r *postgresAuthorRepo) Save(ctx context.Context, param *model.GormAuthor) <-chan ResultRepository {
+	ctxRepo := "AuthorRepositorySave"
+
+	output := make(chan ResultRepository)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxRepo, "recover_repository_save")
+				output <- ResultRepository{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		var row *sql.Row
+		if param.ID > 0 {
+			row = r.write.Raw(`UPDATE `+tableName+` SET name = ?, email = ?, bio = ?, avatar = ?, modified = COALESCE(?, now())
+				WHERE id = ?
+				RETURNING `+authorColumns,
+				param.Name, param.Email, param.Bio, param.Avatar, param.Modified, param.ID).Row()
+		} else {
+			row = r.write.Raw(`INSERT INTO `+tableName+` (name, email, bio, avatar, created)
+				VALUES (?, ?, ?, ?, COALESCE(?, now()))
+				RETURNING `+authorColumns,
+				param.Name, param.Email, param.Bio, param.Avatar, param.Created).Row()
+		}
+
+		author, err := scanAuthor(row)
+		if err == sql.ErrNoRows {
+			output <- ResultRepository{Error: shared.ErrDataNotFound}
+			return
+		}
+
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == pqUniqueViolation {
+			output <- ResultRepository{Error: model.ErrEmailUsed}
+			return
+		}
+
+		if err != nil {
+			utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "save_or_update_author")
+			output <- ResultRepository{Error: err}
+			return
+		}
+
+		output <- ResultRepository{Result: author}
+	}()
+
+	return output
+}
+
+// GetByID function, for find author by its primary ID
+func (r *postgresAuthorRepo) GetByID(ctx context.Context, id int) <-chan ResultRepository {
+	ctxRepo := "AuthorRepositoryGetByID"
+
+	output := make(chan ResultRepository)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxRepo, "recover_repository_get_by_id")
+				output <- ResultRepository{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		row := r.read.Table(tableName).Where("id = ?", id).Select(authorColumns).Row()
+		author, err := scanAuthor(row)
+		if err == sql.ErrNoRows {
+			output <- ResultRepository{Error: shared.ErrDataNotFound}
+			return
+		}
+
+		if err != nil {
+			utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "scan_author")
+			output <- ResultRepository{Error: err}
+			return
+		}
+
+		output <- ResultRepository{Result: author}
+	}()
+
+	return output
+}
+
+// GetByIDs function, for find authors by their primary ID, result is mapped by author id
+func (r *postgresAuthorRepo) GetByIDs(ctx context.Context, ids []int) <-chan ResultRepository {
+	ctxRepo := "AuthorRepositoryGetByIDs"
+
+	output := make(chan ResultRepository)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxRepo, "recover_repository_get_by_ids")
+				output <- ResultRepository{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		authors := make(map[int]model.Author)
+		if len(ids) == 0 {
+			output <- ResultRepository{Result: authors}
+			return
+		}
+
+		rows, err := r.read.Table(tableName).Where("id IN (?)", ids).Select(authorColumns).Rows()
+		if err != nil {
+			utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "query_authors")
+			output <- ResultRepository{Error: err}
+			return
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			author, err := scanAuthor(rows)
+			if err != nil {
+				utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "scan_author")
+				output <- ResultRepository{Error: err}
+				return
+			}
+			authors[author.ID] = author
+		}
+
+		output <- ResultRepository{Result: authors}
+	}()
+
+	return output
+}
+
+// GetAll function, for find list of author by its params ordered by name
+func (r *postgresAuthorRepo) GetAll(ctx context.Context, params model.AuthorParams) <-chan ResultRepository {
+	ctxRepo := "AuthorRepositoryGetAll"
+
+	output := make(chan ResultRepository)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxRepo, "recover_repository_get_all")
+				output <- ResultRepository{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		db := r.filter(r.read.Table(tableName), params).Select(authorColumns).Order("name").Order("id")
+		if params.Limit > 0 {
+			db = db.Offset((params.Page - 1) * params.Limit).Limit(params.Limit)
+		}
+
+		rows, err := db.Rows()
+		if err != nil {
+			utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "query_authors")
+			output <- ResultRepository{Error: err}
+			return
+		}
+		defer rows.Close()
+
+		authors := []model.Author{}
+		for rows.Next() {
+			author, err := scanAuthor(rows)
+			if err != nil {
+				utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "scan_author")
+				output <- ResultRepository{Error: err}
+				return
+			}
+			authors = append(authors, author)
+		}
+
+		output <- ResultRepository{Result: authors}
+	}()
+
+	return output
+}
+
+// GetTotal function, for count total author by its params
+func (r *postgresAuthorRepo) GetTotal(ctx context.Context, params model.AuthorParams) <-chan ResultRepository {
+	ctxRepo := "AuthorRepositoryGetTotal"
+
+	output := make(chan ResultRepository)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxRepo, "recover_repository_get_total")
+				output <- ResultRepository{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		var total int
+		if err := r.filter(r.read.Table(tableName), params).Count(&total).Error; err != nil {
+			utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "count_authors")
+			output <- ResultRepository{Error: err}
+			return
+		}
+
+		output <- ResultRepository{Result: total}
+	}()
+
+	return output
+}
+
+// filter function, for applying author params as where clause
+func (r *postgresAuthorRepo) filter(db *gorm.DB, params model.AuthorParams) *gorm.DB {
+	if params.Query != "" {
+		db = db.Where("name ILIKE ?", "%"+likeEscaper.Replace(params.Query)+"%")
+	}
+	return db
+}
+
+// scanAuthor function, for scanning single author row
+func scanAuthor(row rowScanner) (model.Author, error) {
+	var (
+		author      model.Author
+		bio, avatar sql.NullString
+		modified    pq.NullTime
+	)
+
+	if err := row.Scan(&author.ID, &author.Name, &author.Email, &bio, &avatar, &author.Created, &modified); err != nil {
+		return author, err
+	}
+
+	if bio.Valid {
+		author.Bio = bio.String
+	}
+
+	if avatar.Valid {
+		author.Avatar = avatar.String
+	}
+
+	if modified.Valid {
+		author.Modified = modified.Time.Format(time.RFC3339)
+	}
+
+	return author, nil
+}
@@ -0,0 +1,21 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/willy182/boilerplate-go-cleanarch/src/authors/v1/model"
+)
+
+// ResultUseCase data structure
+type ResultUseCase struct {
+	Result interface{}
+	Error  error
+}
+
+// UseCase use case for author
+type UseCase interface {
+	GetByID(ctx context.Context, ID int) <-chan ResultUseCase
+	GetAll(ctx context.Context, params model.AuthorParams) <-chan ResultUseCase
+	Create(ctx context.Context, param model.AuthorRequest) <-chan ResultUseCase
+	Update(ctx context.Context, ID int, param model.AuthorRequest) <-chan ResultUseCase
+}
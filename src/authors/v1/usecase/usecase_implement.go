@@ -0,0 +1,193 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/willy182/boilerplate-go-cleanarch/src/authors/v1/model"
+	"github.com/willy182/boilerplate-go-cleanarch/src/authors/v1/repository"
+	"github.com/willy182/boilerplate-go-cleanarch/src/shared"
+	"github.com/willy182/boilerplate-go-cleanarch/utils"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type authorUseCase struct {
+	authorRepo repository.Repository
+}
+
+// NewAuthorUseCase use case handler for author
+func NewAuthorUseCase(repo repository.Repository) UseCase {
+	return &authorUseCase{
+		authorRepo: repo,
+	}
+}
+
+// GetByID use case handler for get author by ID
+func (u *authorUseCase) GetByID(ctx context.Context, ID int) <-chan ResultUseCase {
+	ctxUsecase := "author_usecase_get_by_id"
+	output := make(chan ResultUseCase)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxUsecase, "recover_usecase_get_by_id")
+				output <- ResultUseCase{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		res := <-u.authorRepo.GetByID(ctx, ID)
+		if res.Error != nil {
+			utils.Log(log.ErrorLevel, res.Error.Error(), ctxUsecase, "res_repo_get_by_id")
+			output <- ResultUseCase{Error: res.Error}
+			return
+		}
+
+		output <- ResultUseCase{Result: visibleAuthor(ctx, res.Result.(model.Author))}
+	}()
+
+	return output
+}
+
+// GetAll use case handler for get author list with its total records
+func (u *authorUseCase) GetAll(ctx context.Context, params model.AuthorParams) <-chan ResultUseCase {
+	ctxUsecase := "author_usecase_get_all"
+	output := make(chan ResultUseCase)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxUsecase, "recover_usecase_get_all")
+				output <- ResultUseCase{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		authorsChan := u.authorRepo.GetAll(ctx, params)
+		totalChan := u.authorRepo.GetTotal(ctx, params)
+
+		resAuthors := <-authorsChan
+		resTotal := <-totalChan
+
+		if resAuthors.Error != nil {
+			utils.Log(log.ErrorLevel, resAuthors.Error.Error(), ctxUsecase, "res_repo_get_all")
+			output <- ResultUseCase{Error: resAuthors.Error}
+			return
+		}
+
+		if resTotal.Error != nil {
+			utils.Log(log.ErrorLevel, resTotal.Error.Error(), ctxUsecase, "res_repo_get_total")
+			output <- ResultUseCase{Error: resTotal.Error}
+			return
+		}
+
+		authors := resAuthors.Result.([]model.Author)
+		for i := range authors {
+			authors[i] = visibleAuthor(ctx, authors[i])
+		}
+
+		output <- ResultUseCase{Result: model.AuthorList{
+			Data:  authors,
+			Total: resTotal.Result.(int),
+		}}
+	}()
+
+	return output
+}
+
+// Create use case handler for create new author, only admin can manage authors
+func (u *authorUseCase) Create(ctx context.Context, param model.AuthorRequest) <-chan ResultUseCase {
+	ctxUsecase := "author_usecase_create"
+	output := make(chan ResultUseCase)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxUsecase, "recover_usecase_create")
+				output <- ResultUseCase{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		if !shared.CallerFromContext(ctx).IsAdmin() {
+			output <- ResultUseCase{Error: shared.ErrForbidden}
+			return
+		}
+
+		now := time.Now()
+		author := &model.GormAuthor{
+			Name:    param.Name,
+			Email:   param.Email,
+			Bio:     param.Bio,
+			Avatar:  param.Avatar,
+			Created: &now,
+		}
+
+		res := <-u.authorRepo.Save(ctx, author)
+		if res.Error != nil {
+			utils.Log(log.ErrorLevel, res.Error.Error(), ctxUsecase, "res_repo_save")
+			output <- ResultUseCase{Error: res.Error}
+			return
+		}
+
+		output <- ResultUseCase{Result: res.Result.(model.Author)}
+	}()
+
+	return output
+}
+
+// Update use case handler for replace all field of existing author, only admin can manage authors
+func (u *authorUseCase) Update(ctx context.Context, ID int, param model.AuthorRequest) <-chan ResultUseCase {
+	ctxUsecase := "author_usecase_update"
+	output := make(chan ResultUseCase)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxUsecase, "recover_usecase_update")
+				output <- ResultUseCase{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		if !shared.CallerFromContext(ctx).IsAdmin() {
+			output <- ResultUseCase{Error: shared.ErrForbidden}
+			return
+		}
+
+		now := time.Now()
+		author := &model.GormAuthor{
+			ID:       ID,
+			Name:     param.Name,
+			Email:    param.Email,
+			Bio:      param.Bio,
+			Avatar:   param.Avatar,
+			Modified: &now,
+		}
+
+		res := <-u.authorRepo.Save(ctx, author)
+		if res.Error != nil {
+			utils.Log(log.ErrorLevel, res.Error.Error(), ctxUsecase, "res_repo_save")
+			output <- ResultUseCase{Error: res.Error}
+			return
+		}
+
+		output <- ResultUseCase{Result: res.Result.(model.Author)}
+	}()
+
+	return output
+}
+
+// visibleAuthor function for hiding contact of author from public caller
+func visibleAuthor(ctx context.Context, author model.Author) model.Author {
+	if !shared.CallerFromContext(ctx).IsPrivileged() {
+		author.Email = ""
+	}
+	return author
+}
@@ -0,0 +1,258 @@
+package delivery
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/willy182/boilerplate-go-cleanarch/src/comments/v1/model"
+	"github.com/willy182/boilerplate-go-cleanarch/src/comments/v1/usecase"
+	"github.com/willy182/boilerplate-go-cleanarch/src/shared"
+	"github.com/willy182/boilerplate-go-cleanarch/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	log "github.com/sirupsen/logrus"
+)
+
+// CommentHandler struct for http comment handling
+type CommentHandler struct {
+	CommentUseCase usecase.UseCase
+}
+
+// NewCommentHTTPHandler route handler for comment
+func NewCommentHTTPHandler(usecase usecase.UseCase) *CommentHandler {
+	return &CommentHandler{CommentUseCase: usecase}
+}
+
+// Mount function
+func (h *CommentHandler) Mount(group *gin.RouterGroup) {
+	group.GET("/comments", h.GetAll)
+	group.POST("/comment/:id/status", h.UpdateStatus)
+	group.GET("/article/:id/comments", h.GetByArticle)
+	group.POST("/article/:id/comments", h.Create)
+}
+
+// validateID function for validating id route param, response will be written when id is not valid
+func (h *CommentHandler) validateID(c *gin.Context, ctxHandler string) (int, bool) {
+	param := c.Param("id")
+	multiError := shared.NewMultiError()
+
+	if ok := shared.ValidateNumeric(param); !ok {
+		multiError.Append("error", fmt.Errorf("id must be numeric"))
+		utils.Log(log.ErrorLevel, multiError.Error(), ctxHandler, "validate_id")
+		response := shared.NewHTTPResponse(http.StatusBadRequest, "validate id", multiError)
+		response.JSON(c.Writer)
+		return 0, false
+	}
+
+	value, _ := strconv.Atoi(param)
+	return value, true
+}
+
+// buildCommentParams function for converting comment list query params into comment params,
+// response will be written when the params are not valid
+func (h *CommentHandler) buildCommentParams(c *gin.Context, ctxHandler string) (model.CommentParams, bool) {
+	multiError := shared.NewMultiError()
+
+	var params model.CommentRequestParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		multiError.Append("error", err)
+		utils.Log(log.ErrorLevel, multiError.Error(), ctxHandler, "bind_params")
+		response := shared.NewHTTPResponse(http.StatusBadRequest, "bind params", multiError)
+		response.JSON(c.Writer)
+		return model.CommentParams{}, false
+	}
+
+	var commentParams model.CommentParams
+	commentParams.Page, commentParams.Limit = shared.ParsePagination(params.PageNumber, params.PageSize, multiError)
+
+	if params.Status != "" {
+		for _, status := range strings.Split(params.Status, ",") {
+			status = strings.TrimSpace(status)
+			if !validStatus(status) {
+				multiError.Append("status", fmt.Errorf("status must be one of %s", strings.Join(model.CommentStatuses, ", ")))
+				break
+			}
+			commentParams.Status = append(commentParams.Status, status)
+		}
+	}
+
+	if params.ArticleID != "" {
+		if ok := shared.ValidateNumeric(params.ArticleID); !ok {
+			multiError.Append("article_id", fmt.Errorf("article_id must be numeric"))
+		}
+		commentParams.ArticleID, _ = strconv.Atoi(params.ArticleID)
+	}
+
+	if multiError.HasError() {
+		utils.Log(log.ErrorLevel, multiError.Error(), ctxHandler, "validate_params")
+		response := shared.NewHTTPResponse(http.StatusBadRequest, "validate params", multiError)
+		response.JSON(c.Writer)
+		return model.CommentParams{}, false
+	}
+
+	return commentParams, true
+}
+
+// validStatus function for checking comment status
+func validStatus(status string) bool {
+	for _, valid := range model.CommentStatuses {
+		if status == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// errorStatusCode function for mapping use case error into http status code
+func errorStatusCode(err error) int {
+	switch {
+	case errors.Is(err, shared.ErrDataNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, shared.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, model.ErrParentNotFound), errors.Is(err, model.ErrCommentsClosed):
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+// GetByArticle method for handling route threaded comments of article
+func (h *CommentHandler) GetByArticle(c *gin.Context) {
+	ctxHandler := "comment_handler_get_by_article"
+	ctx := c.Request.Context()
+	multiError := shared.NewMultiError()
+
+	id, ok := h.validateID(c, ctxHandler)
+	if !ok {
+		return
+	}
+
+	params, ok := h.buildCommentParams(c, ctxHandler)
+	if !ok {
+		return
+	}
+
+	res := <-h.CommentUseCase.GetByArticle(ctx, id, params)
+	if res.Error != nil {
+		utils.Log(log.ErrorLevel, res.Error.Error(), ctxHandler, "err_res_get_by_article")
+		response := shared.NewHTTPResponse(errorStatusCode(res.Error), res.Error.Error(), multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	result := res.Result.(model.CommentList)
+	meta := shared.CreateMeta(result.Total, params.Page, params.Limit)
+	response := shared.NewHTTPResponse(http.StatusOK, "Comment List", result.Data, meta)
+	response.JSON(c.Writer)
+}
+
+// GetAll method for handling route comment moderation list
+func (h *CommentHandler) GetAll(c *gin.Context) {
+	ctxHandler := "comment_handler_get_all"
+	ctx := c.Request.Context()
+	multiError := shared.NewMultiError()
+
+	params, ok := h.buildCommentParams(c, ctxHandler)
+	if !ok {
+		return
+	}
+
+	res := <-h.CommentUseCase.GetAll(ctx, params)
+	if res.Error != nil {
+		utils.Log(log.ErrorLevel, res.Error.Error(), ctxHandler, "err_res_get_all")
+		response := shared.NewHTTPResponse(errorStatusCode(res.Error), res.Error.Error(), multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	result := res.Result.(model.CommentList)
+	meta := shared.CreateMeta(result.Total, params.Page, params.Limit)
+	response := shared.NewHTTPResponse(http.StatusOK, "Comment List", result.Data, meta)
+	response.JSON(c.Writer)
+}
+
+// Create method for handling route create comment of article
+func (h *CommentHandler) Create(c *gin.Context) {
+	ctxHandler := "comment_handler_create"
+	ctx := c.Request.Context()
+	multiError := shared.NewMultiError()
+
+	id, ok := h.validateID(c, ctxHandler)
+	if !ok {
+		return
+	}
+
+	// payload is validated again after trimming so whitespace only field is rejected as missing
+	var param model.CommentRequest
+	err := c.ShouldBindJSON(&param)
+	if err == nil {
+		param.Trim()
+		err = binding.Validator.ValidateStruct(param)
+	}
+
+	if err != nil {
+		multiError.Append("error", err)
+		utils.Log(log.ErrorLevel, multiError.Error(), ctxHandler, "bind_payload")
+		response := shared.NewHTTPResponse(http.StatusBadRequest, "bind payload", multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	res := <-h.CommentUseCase.Create(ctx, id, param)
+	if res.Error != nil {
+		utils.Log(log.ErrorLevel, res.Error.Error(), ctxHandler, "err_res_create")
+		response := shared.NewHTTPResponse(errorStatusCode(res.Error), res.Error.Error(), multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	result := res.Result.(model.Comment)
+	response := shared.NewHTTPResponse(http.StatusCreated, "Comment Created", result)
+	response.JSON(c.Writer)
+}
+
+// UpdateStatus method for handling route moderate comment by ID
+func (h *CommentHandler) UpdateStatus(c *gin.Context) {
+	ctxHandler := "comment_handler_update_status"
+	ctx := c.Request.Context()
+	multiError := shared.NewMultiError()
+
+	id, ok := h.validateID(c, ctxHandler)
+	if !ok {
+		return
+	}
+
+	var param model.CommentStatusRequest
+	if err := c.ShouldBindJSON(&param); err != nil {
+		multiError.Append("error", err)
+		utils.Log(log.ErrorLevel, multiError.Error(), ctxHandler, "bind_payload")
+		response := shared.NewHTTPResponse(http.StatusBadRequest, "bind payload", multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	if !validStatus(param.Status) {
+		multiError.Append("status", fmt.Errorf("status must be one of %s", strings.Join(model.CommentStatuses, ", ")))
+		utils.Log(log.ErrorLevel, multiError.Error(), ctxHandler, "validate_status")
+		response := shared.NewHTTPResponse(http.StatusBadRequest, "validate status", multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	res := <-h.CommentUseCase.UpdateStatus(ctx, id, param.Status)
+	if res.Error != nil {
+		utils.Log(log.ErrorLevel, res.Error.Error(), ctxHandler, "err_res_update_status")
+		response := shared.NewHTTPResponse(errorStatusCode(res.Error), res.Error.Error(), multiError)
+		response.JSON(c.Writer)
+		return
+	}
+
+	result := res.Result.(model.Comment)
+	response := shared.NewHTTPResponse(http.StatusOK, "Comment Status Updated", result)
+	response.JSON(c.Writer)
+}
@@ -0,0 +1,100 @@
+package model
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+const (
+	// StatusPending status of comment that is waiting for moderation
+	StatusPending = "pending"
+	// StatusApproved status of comment that is visible to public
+	StatusApproved = "approved"
+	// StatusRejected status of comment that is hidden by moderator
+	StatusRejected = "rejected"
+	// StatusSpam status of comment that is marked as spam by moderator
+	StatusSpam = "spam"
+)
+
+var (
+	// ErrParentNotFound error when replied comment does not exist on the same article or is not approved
+	ErrParentNotFound = errors.New("parent comment does not exist")
+	// ErrCommentsClosed error when comment is posted on article that is not published
+	ErrCommentsClosed = errors.New("comments are only open on published article")
+)
+
+// CommentStatuses list of valid comment status
+var CommentStatuses = []string{StatusPending, StatusApproved, StatusRejected, StatusSpam}
+
+// GormComment data of struct
+type GormComment struct {
+	ID          int        `gorm:"AUTO_INCREMENT;PRIMARY_KEY"`
+	ArticleID   int        `gorm:"NOT NULL;index"`
+	ParentID    *int       `gorm:"index"`
+	AuthorName  string     `gorm:"type:varchar(100);NOT NULL"`
+	AuthorEmail string     `gorm:"type:varchar(150);NOT NULL"`
+	Body        string     `gorm:"type:text;NOT NULL"`
+	Status      string     `gorm:"type:varchar(20);NOT NULL;DEFAULT:'pending'"`
+	Created     *time.Time `gorm:"type:timestamp(6) with time zone;NOT NULL"`
+	Modified    *time.Time `gorm:"type:timestamp(6) with time zone"`
+}
+
+// Comment data of struct, Replies is only filled on threaded comment list
+type Comment struct {
+	ID          int       `json:"id"`
+	ArticleID   int       `json:"articleId"`
+	ParentID    int       `json:"parentId,omitempty"`
+	AuthorName  string    `json:"authorName"`
+	AuthorEmail string    `json:"authorEmail,omitempty"`
+	Body        string    `json:"body"`
+	Status      string    `json:"status"`
+	Created     time.Time `json:"created"`
+	Modified    string    `json:"modified,omitempty"`
+	Replies     []Comment `json:"replies,omitempty"`
+}
+
+// CommentRequest data of struct for create comment payload, ParentID is the replied comment
+type CommentRequest struct {
+	ParentID    int    `json:"parentId" binding:"omitempty,min=1"`
+	AuthorName  string `json:"authorName" binding:"required,max=100"`
+	AuthorEmail string `json:"authorEmail" binding:"required,email,max=150"`
+	Body        string `json:"body" binding:"required,max=5000"`
+}
+
+// Trim function for removing surrounding whitespace of comment request,
+// the request must be validated after trimming so blank body does not pass required
+func (r *CommentRequest) Trim() {
+	r.AuthorName = strings.TrimSpace(r.AuthorName)
+	r.AuthorEmail = strings.TrimSpace(r.AuthorEmail)
+	r.Body = strings.TrimSpace(r.Body)
+}
+
+// CommentStatusRequest data of struct for moderate comment payload
+type CommentStatusRequest struct {
+	Status string `json:"status" binding:"required"`
+}
+
+// CommentRequestParams data of struct for comment list query params
+type CommentRequestParams struct {
+	PageNumber string `form:"page"`
+	PageSize   string `form:"limit"`
+	Status     string `form:"status"`
+	ArticleID  string `form:"article_id"`
+}
+
+// CommentParams data of struct for filtering comment list, empty Status means any status,
+// TopLevel only lists comments that are not a reply
+type CommentParams struct {
+	Page      int
+	Limit     int
+	Status    []string
+	ArticleID int
+	TopLevel  bool
+}
+
+// CommentList data of struct for comment list with its total records
+type CommentList struct {
+	Data  []Comment
+	Total int
+}
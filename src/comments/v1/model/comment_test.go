@@ -0,0 +1,34 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/gin-gonic/gin/binding"
+)
+
+func TestCommentRequestTrim(t *testing.T) {
+	tests := []struct {
+		name    string
+		request CommentRequest
+		body    string
+		valid   bool
+	}{
+		{"valid", CommentRequest{AuthorName: " Ann ", AuthorEmail: "ann@example.com ", Body: "\n nice post \t"}, "nice post", true},
+		{"blank body", CommentRequest{AuthorName: "Ann", AuthorEmail: "ann@example.com", Body: "   "}, "", false},
+		{"blank name", CommentRequest{AuthorName: " \t", AuthorEmail: "ann@example.com", Body: "nice"}, "nice", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.request.Trim()
+			if tt.request.Body != tt.body {
+				t.Errorf("body = %q, expected %q", tt.request.Body, tt.body)
+			}
+
+			err := binding.Validator.ValidateStruct(tt.request)
+			if (err == nil) != tt.valid {
+				t.Errorf("validation error = %v, expected valid %v", err, tt.valid)
+			}
+		})
+	}
+}
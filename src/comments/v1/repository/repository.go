@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/willy182/boilerplate-go-cleanarch/src/comments/v1/model"
+)
+
+// ResultRepository data structure
+type ResultRepository struct {
+	Result interface{}
+	Error  error
+}
+
+// Repository interface for comment repository
+type Repository interface {
+	Save(ctx context.Context, param *model.GormComment) <-chan ResultRepository
+	GetByID(ctx context.Context, ID int) <-chan ResultRepository
+	UpdateStatus(ctx context.Context, ID int, status string) <-chan ResultRepository
+	GetAll(ctx context.Context, params model.CommentParams) <-chan ResultRepository
+	GetTotal(ctx context.Context, params model.CommentParams) <-chan ResultRepository
+	GetReplies(ctx context.Context, parentIDs []int, statuses []string) <-chan ResultRepository
+}
@@ -0,0 +1,422 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/willy182/boilerplate-go-cleanarch/src/comments/v1/model"
+	"github.com/willy182/boilerplate-go-cleanarch/src/shared"
+	"github.com/willy182/boilerplate-go-cleanarch/utils"
+
+	"github.com/jinzhu/gorm"
+	"github.com/lib/pq"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	tableName        = "comments"
+	articleTableName = "articles"
+	commentColumns   = "id, article_id, parent_id, author_name, author_email, body, status, created, modified"
+)
+
+// rowScanner abstraction of *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// postgresCommentRepo struct
+type postgresCommentRepo struct {
+	read  *gorm.DB
+	write *gorm.DB
+}
+
+// NewPostgresCommentRepository comment repository postgres handler
+func NewPostgresCommentRepository(read, write *gorm.DB) Repository {
+	return &postgresCommentRepo{
+		read:  read,
+		write: write,
+	}
+}
+
+// Save function, for insert new comment, comment count of its article is recounted in the same transaction
+// when the comment is saved as approved, the persisted row is returned as model.Comment
+func (r *postgresCommentRepo) Save(ctx context.Context, param *model.GormComment) <-chan ResultRepository {
+	ctxRepo := "CommentRepositorySave"
+
+	output := make(chan ResultRepository)
+
+	go func() {
+		// begin
+		tx := r.write.Begin()
+
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxRepo, "recover_repository_save")
+				tx.Rollback()
+				output <- ResultRepository{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		if err := tx.Error; err != nil {
+			utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "tx_error")
+			output <- ResultRepository{Error: err}
+			return
+		}
+
+		row := tx.Raw(`INSERT INTO `+tableName+` (article_id, parent_id, author_name, author_email, body, status, created)
+			VALUES (?, ?, ?, ?, ?, ?, COALESCE(?, now()))
+			RETURNING `+commentColumns,
+			param.ArticleID, param.ParentID, param.AuthorName, param.AuthorEmail, param.Body, param.Status, param.Created).Row()
+
+		comment, err := scanComment(row)
+		if err != nil {
+			utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "save_comment")
+			tx.Rollback()
+			output <- ResultRepository{Error: err}
+			return
+		}
+
+		if countDelta("", comment.Status) != 0 {
+			if err := r.recountComments(tx, comment.ArticleID); err != nil {
+				utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "recount_comments")
+				tx.Rollback()
+				output <- ResultRepository{Error: err}
+				return
+			}
+		}
+
+		if err := tx.Commit().Error; err != nil {
+			utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "tx_commit")
+			output <- ResultRepository{Error: err}
+			return
+		}
+
+		output <- ResultRepository{Result: comment}
+	}()
+
+	return output
+}
+
+// GetByID function, for find comment by its primary ID
+func (r *postgresCommentRepo) GetByID(ctx context.Context, id int) <-chan ResultRepository {
+	ctxRepo := "CommentRepositoryGetByID"
+
+	output := make(chan ResultRepository)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxRepo, "recover_repository_get_by_id")
+				output <- ResultRepository{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		row := r.read.Table(tableName).Where("id = ?", id).Select(commentColumns).Row()
+		comment, err := scanComment(row)
+		if err == sql.ErrNoRows {
+			output <- ResultRepository{Error: shared.ErrDataNotFound}
+			return
+		}
+
+		if err != nil {
+			utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "scan_comment")
+			output <- ResultRepository{Error: err}
+			return
+		}
+
+		output <- ResultRepository{Result: comment}
+	}()
+
+	return output
+}
+
+// UpdateStatus function, for moderating comment, the current status is locked so comment count of its article
+// is recounted in the same transaction only when the comment is approved or no longer approved
+func (r *postgresCommentRepo) UpdateStatus(ctx context.Context, id int, status string) <-chan ResultRepository {
+	ctxRepo := "CommentRepositoryUpdateStatus"
+
+	output := make(chan ResultRepository)
+
+	go func() {
+		// begin
+		tx := r.write.Begin()
+
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxRepo, "recover_repository_update_status")
+				tx.Rollback()
+				output <- ResultRepository{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		if err := tx.Error; err != nil {
+			utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "tx_error")
+			output <- ResultRepository{Error: err}
+			return
+		}
+
+		var oldStatus string
+		errLock := tx.Raw(`SELECT status FROM `+tableName+` WHERE id = ? FOR UPDATE`, id).Row().Scan(&oldStatus)
+		if errLock == sql.ErrNoRows {
+			tx.Rollback()
+			output <- ResultRepository{Error: shared.ErrDataNotFound}
+			return
+		}
+
+		if errLock != nil {
+			utils.Log(log.ErrorLevel, errLock.Error(), ctxRepo, "lock_comment_status")
+			tx.Rollback()
+			output <- ResultRepository{Error: errLock}
+			return
+		}
+
+		row := tx.Raw(`UPDATE `+tableName+` SET status = ?, modified = now()
+			WHERE id = ?
+			RETURNING `+commentColumns, status, id).Row()
+		comment, err := scanComment(row)
+		if err != nil {
+			utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "update_status_comment")
+			tx.Rollback()
+			output <- ResultRepository{Error: err}
+			return
+		}
+
+		if countDelta(oldStatus, comment.Status) != 0 {
+			if err := r.recountComments(tx, comment.ArticleID); err != nil {
+				utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "recount_comments")
+				tx.Rollback()
+				output <- ResultRepository{Error: err}
+				return
+			}
+		}
+
+		if err := tx.Commit().Error; err != nil {
+			utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "tx_commit")
+			output <- ResultRepository{Error: err}
+			return
+		}
+
+		output <- ResultRepository{Result: comment}
+	}()
+
+	return output
+}
+
+// GetAll function, for find list of comment by its params, newest first
+func (r *postgresCommentRepo) GetAll(ctx context.Context, params model.CommentParams) <-chan ResultRepository {
+	ctxRepo := "CommentRepositoryGetAll"
+
+	output := make(chan ResultRepository)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxRepo, "recover_repository_get_all")
+				output <- ResultRepository{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		db := r.filter(r.read.Table(tableName), params).Select(commentColumns).Order("created DESC").Order("id DESC")
+		if params.Limit > 0 {
+			db = db.Offset((params.Page - 1) * params.Limit).Limit(params.Limit)
+		}
+
+		rows, err := db.Rows()
+		if err != nil {
+			utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "query_comments")
+			output <- ResultRepository{Error: err}
+			return
+		}
+		defer rows.Close()
+
+		comments := []model.Comment{}
+		for rows.Next() {
+			comment, err := scanComment(rows)
+			if err != nil {
+				utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "scan_comment")
+				output <- ResultRepository{Error: err}
+				return
+			}
+			comments = append(comments, comment)
+		}
+
+		output <- ResultRepository{Result: comments}
+	}()
+
+	return output
+}
+
+// GetTotal function, for count total comment by its params
+func (r *postgresCommentRepo) GetTotal(ctx context.Context, params model.CommentParams) <-chan ResultRepository {
+	ctxRepo := "CommentRepositoryGetTotal"
+
+	output := make(chan ResultRepository)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxRepo, "recover_repository_get_total")
+				output <- ResultRepository{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		var total int
+		if err := r.filter(r.read.Table(tableName), params).Count(&total).Error; err != nil {
+			utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "count_comments")
+			output <- ResultRepository{Error: err}
+			return
+		}
+
+		output <- ResultRepository{Result: total}
+	}()
+
+	return output
+}
+
+// GetReplies function, for find every reply below parent comments at any depth oldest first,
+// reply is only followed through comments in one of statuses, empty statuses means any status
+func (r *postgresCommentRepo) GetReplies(ctx context.Context, parentIDs []int, statuses []string) <-chan ResultRepository {
+	ctxRepo := "CommentRepositoryGetReplies"
+
+	output := make(chan ResultRepository)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxRepo, "recover_repository_get_replies")
+				output <- ResultRepository{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		replies := []model.Comment{}
+		if len(parentIDs) == 0 {
+			output <- ResultRepository{Result: replies}
+			return
+		}
+
+		condition, args := "", []interface{}{parentIDs}
+		if len(statuses) > 0 {
+			condition = " AND " + tableName + ".status IN (?)"
+			args = append(args, statuses, statuses)
+		}
+
+		columns := tableName + "." + strings.Replace(commentColumns, ", ", ", "+tableName+".", -1)
+		rows, err := r.read.Raw(`WITH RECURSIVE replies AS (
+				SELECT `+columns+` FROM `+tableName+`
+				WHERE `+tableName+`.parent_id IN (?)`+condition+`
+				UNION ALL
+				SELECT `+columns+` FROM `+tableName+`
+				JOIN replies ON `+tableName+`.parent_id = replies.id
+				WHERE true`+condition+`
+			)
+			SELECT `+commentColumns+` FROM replies ORDER BY created, id`, args...).Rows()
+		if err != nil {
+			utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "query_replies")
+			output <- ResultRepository{Error: err}
+			return
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			reply, err := scanComment(rows)
+			if err != nil {
+				utils.Log(log.ErrorLevel, err.Error(), ctxRepo, "scan_comment")
+				output <- ResultRepository{Error: err}
+				return
+			}
+			replies = append(replies, reply)
+		}
+
+		output <- ResultRepository{Result: replies}
+	}()
+
+	return output
+}
+
+// filter function, for applying comment params as where clause
+func (r *postgresCommentRepo) filter(db *gorm.DB, params model.CommentParams) *gorm.DB {
+	if params.ArticleID > 0 {
+		db = db.Where("article_id = ?", params.ArticleID)
+	}
+
+	if len(params.Status) > 0 {
+		db = db.Where("status IN (?)", params.Status)
+	}
+
+	if params.TopLevel {
+		db = db.Where("parent_id IS NULL")
+	}
+	return db
+}
+
+// recountComments function, for recounting comment count of article inside transaction tx,
+// only approved comment whose every ancestor is approved is counted, the same comments that are shown in threads,
+// so rejecting a comment also takes its approved replies out of the count,
+// the article is locked before counting so concurrent moderation of the same article is counted one after another,
+// the lock is FOR NO KEY UPDATE so it does not wait for comment inserted by another transaction referencing the article
+func (r *postgresCommentRepo) recountComments(tx *gorm.DB, articleID int) error {
+	if err := tx.Exec(`SELECT 1 FROM `+articleTableName+` WHERE id = ? FOR NO KEY UPDATE`, articleID).Error; err != nil {
+		return err
+	}
+
+	return tx.Exec(`WITH RECURSIVE visible AS (
+			SELECT id FROM `+tableName+` WHERE article_id = ? AND parent_id IS NULL AND status = ?
+			UNION ALL
+			SELECT c.id FROM `+tableName+` c JOIN visible ON c.parent_id = visible.id WHERE c.status = ?
+		)
+		UPDATE `+articleTableName+` SET comment_count = (SELECT count(*) FROM visible) WHERE id = ?`,
+		articleID, model.StatusApproved, model.StatusApproved, articleID).Error
+}
+
+// countDelta function, for getting change of approved comments when status is moved from oldStatus into newStatus,
+// comment count of article only has to be recounted when it is not zero
+func countDelta(oldStatus, newStatus string) int {
+	switch {
+	case oldStatus != model.StatusApproved && newStatus == model.StatusApproved:
+		return 1
+	case oldStatus == model.StatusApproved && newStatus != model.StatusApproved:
+		return -1
+	default:
+		return 0
+	}
+}
+
+// scanComment function, for scanning single comment row of commentColumns
+func scanComment(row rowScanner) (model.Comment, error) {
+	var (
+		comment  model.Comment
+		parentID sql.NullInt64
+		modified pq.NullTime
+	)
+
+	err := row.Scan(&comment.ID, &comment.ArticleID, &parentID, &comment.AuthorName, &comment.AuthorEmail,
+		&comment.Body, &comment.Status, &comment.Created, &modified)
+	if err != nil {
+		return comment, err
+	}
+
+	if parentID.Valid {
+		comment.ParentID = int(parentID.Int64)
+	}
+
+	if modified.Valid {
+		comment.Modified = modified.Time.Format(time.RFC3339)
+	}
+
+	return comment, nil
+}
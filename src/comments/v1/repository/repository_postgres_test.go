@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/willy182/boilerplate-go-cleanarch/src/comments/v1/model"
+)
+
+func TestCountDelta(t *testing.T) {
+	tests := []struct {
+		name      string
+		oldStatus string
+		newStatus string
+		expected  int
+	}{
+		{"new pending", "", model.StatusPending, 0},
+		{"new approved", "", model.StatusApproved, 1},
+		{"approve pending", model.StatusPending, model.StatusApproved, 1},
+		{"approve spam", model.StatusSpam, model.StatusApproved, 1},
+		{"approve again", model.StatusApproved, model.StatusApproved, 0},
+		{"reject approved", model.StatusApproved, model.StatusRejected, -1},
+		{"spam approved", model.StatusApproved, model.StatusSpam, -1},
+		{"back to pending", model.StatusApproved, model.StatusPending, -1},
+		{"reject pending", model.StatusPending, model.StatusRejected, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if actual := countDelta(tt.oldStatus, tt.newStatus); actual != tt.expected {
+				t.Errorf("countDelta(%q, %q) = %d, expected %d", tt.oldStatus, tt.newStatus, actual, tt.expected)
+			}
+		})
+	}
+}
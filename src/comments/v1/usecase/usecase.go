@@ -0,0 +1,21 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/willy182/boilerplate-go-cleanarch/src/comments/v1/model"
+)
+
+// ResultUseCase data structure
+type ResultUseCase struct {
+	Result interface{}
+	Error  error
+}
+
+// UseCase use case for comment
+type UseCase interface {
+	GetByArticle(ctx context.Context, articleID int, params model.CommentParams) <-chan ResultUseCase
+	GetAll(ctx context.Context, params model.CommentParams) <-chan ResultUseCase
+	Create(ctx context.Context, articleID int, param model.CommentRequest) <-chan ResultUseCase
+	UpdateStatus(ctx context.Context, ID int, status string) <-chan ResultUseCase
+}
@@ -0,0 +1,311 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	articleModel "github.com/willy182/boilerplate-go-cleanarch/src/articles/v1/model"
+	articleRepository "github.com/willy182/boilerplate-go-cleanarch/src/articles/v1/repository"
+	"github.com/willy182/boilerplate-go-cleanarch/src/comments/v1/model"
+	"github.com/willy182/boilerplate-go-cleanarch/src/comments/v1/repository"
+	"github.com/willy182/boilerplate-go-cleanarch/src/shared"
+	"github.com/willy182/boilerplate-go-cleanarch/utils"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type commentUseCase struct {
+	commentRepo repository.Repository
+	articleRepo articleRepository.Repository
+}
+
+// NewCommentUseCase use case handler for comment
+func NewCommentUseCase(repo repository.Repository, articleRepo articleRepository.Repository) UseCase {
+	return &commentUseCase{
+		commentRepo: repo,
+		articleRepo: articleRepo,
+	}
+}
+
+// GetByArticle use case handler for get threaded comments of article, page is applied to top level comments
+// and each of them has every reply below it, public only sees approved comments of published article
+func (u *commentUseCase) GetByArticle(ctx context.Context, articleID int, params model.CommentParams) <-chan ResultUseCase {
+	ctxUsecase := "comment_usecase_get_by_article"
+	output := make(chan ResultUseCase)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxUsecase, "recover_usecase_get_by_article")
+				output <- ResultUseCase{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		if _, err := u.visibleArticle(ctx, articleID); err != nil {
+			utils.Log(log.ErrorLevel, err.Error(), ctxUsecase, "visible_article")
+			output <- ResultUseCase{Error: err}
+			return
+		}
+
+		params = visibleParams(ctx, params)
+		params.ArticleID = articleID
+		params.TopLevel = true
+
+		commentsChan := u.commentRepo.GetAll(ctx, params)
+		totalChan := u.commentRepo.GetTotal(ctx, params)
+
+		resComments := <-commentsChan
+		resTotal := <-totalChan
+
+		if resComments.Error != nil {
+			utils.Log(log.ErrorLevel, resComments.Error.Error(), ctxUsecase, "res_repo_get_all")
+			output <- ResultUseCase{Error: resComments.Error}
+			return
+		}
+
+		if resTotal.Error != nil {
+			utils.Log(log.ErrorLevel, resTotal.Error.Error(), ctxUsecase, "res_repo_get_total")
+			output <- ResultUseCase{Error: resTotal.Error}
+			return
+		}
+
+		comments := resComments.Result.([]model.Comment)
+		ids := make([]int, 0, len(comments))
+		for _, comment := range comments {
+			ids = append(ids, comment.ID)
+		}
+
+		resReplies := <-u.commentRepo.GetReplies(ctx, ids, params.Status)
+		if resReplies.Error != nil {
+			utils.Log(log.ErrorLevel, resReplies.Error.Error(), ctxUsecase, "res_repo_get_replies")
+			output <- ResultUseCase{Error: resReplies.Error}
+			return
+		}
+
+		comments = buildThreads(comments, resReplies.Result.([]model.Comment))
+		for i := range comments {
+			visibleComment(ctx, &comments[i])
+		}
+
+		output <- ResultUseCase{Result: model.CommentList{Data: comments, Total: resTotal.Result.(int)}}
+	}()
+
+	return output
+}
+
+// GetAll use case handler for get flat comment list of every article newest first as moderation queue,
+// only privileged caller can see it
+func (u *commentUseCase) GetAll(ctx context.Context, params model.CommentParams) <-chan ResultUseCase {
+	ctxUsecase := "comment_usecase_get_all"
+	output := make(chan ResultUseCase)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxUsecase, "recover_usecase_get_all")
+				output <- ResultUseCase{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		if !shared.CallerFromContext(ctx).IsPrivileged() {
+			output <- ResultUseCase{Error: shared.ErrForbidden}
+			return
+		}
+
+		commentsChan := u.commentRepo.GetAll(ctx, params)
+		totalChan := u.commentRepo.GetTotal(ctx, params)
+
+		resComments := <-commentsChan
+		resTotal := <-totalChan
+
+		if resComments.Error != nil {
+			utils.Log(log.ErrorLevel, resComments.Error.Error(), ctxUsecase, "res_repo_get_all")
+			output <- ResultUseCase{Error: resComments.Error}
+			return
+		}
+
+		if resTotal.Error != nil {
+			utils.Log(log.ErrorLevel, resTotal.Error.Error(), ctxUsecase, "res_repo_get_total")
+			output <- ResultUseCase{Error: resTotal.Error}
+			return
+		}
+
+		output <- ResultUseCase{Result: model.CommentList{Data: resComments.Result.([]model.Comment), Total: resTotal.Result.(int)}}
+	}()
+
+	return output
+}
+
+// Create use case handler for posting comment or reply on published article, comment of public caller waits for moderation,
+// comment of privileged caller is approved at once, reply is only allowed on approved comment of the same article
+func (u *commentUseCase) Create(ctx context.Context, articleID int, param model.CommentRequest) <-chan ResultUseCase {
+	ctxUsecase := "comment_usecase_create"
+	output := make(chan ResultUseCase)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxUsecase, "recover_usecase_create")
+				output <- ResultUseCase{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		article, err := u.visibleArticle(ctx, articleID)
+		if err != nil {
+			utils.Log(log.ErrorLevel, err.Error(), ctxUsecase, "visible_article")
+			output <- ResultUseCase{Error: err}
+			return
+		}
+
+		if article.Status != articleModel.StatusPublished {
+			output <- ResultUseCase{Error: model.ErrCommentsClosed}
+			return
+		}
+
+		var parentID *int
+		if param.ParentID > 0 {
+			resParent := <-u.commentRepo.GetByID(ctx, param.ParentID)
+			if errors.Is(resParent.Error, shared.ErrDataNotFound) {
+				output <- ResultUseCase{Error: model.ErrParentNotFound}
+				return
+			}
+
+			if resParent.Error != nil {
+				utils.Log(log.ErrorLevel, resParent.Error.Error(), ctxUsecase, "res_repo_get_parent")
+				output <- ResultUseCase{Error: resParent.Error}
+				return
+			}
+
+			parent := resParent.Result.(model.Comment)
+			if parent.ArticleID != articleID || parent.Status != model.StatusApproved {
+				output <- ResultUseCase{Error: model.ErrParentNotFound}
+				return
+			}
+			parentID = &parent.ID
+		}
+
+		status := model.StatusPending
+		if shared.CallerFromContext(ctx).IsPrivileged() {
+			status = model.StatusApproved
+		}
+
+		now := time.Now()
+		res := <-u.commentRepo.Save(ctx, &model.GormComment{
+			ArticleID:   articleID,
+			ParentID:    parentID,
+			AuthorName:  param.AuthorName,
+			AuthorEmail: param.AuthorEmail,
+			Body:        param.Body,
+			Status:      status,
+			Created:     &now,
+		})
+		if res.Error != nil {
+			utils.Log(log.ErrorLevel, res.Error.Error(), ctxUsecase, "res_repo_save")
+			output <- ResultUseCase{Error: res.Error}
+			return
+		}
+
+		output <- ResultUseCase{Result: res.Result.(model.Comment)}
+	}()
+
+	return output
+}
+
+// UpdateStatus use case handler for moderating comment, only privileged caller can moderate
+func (u *commentUseCase) UpdateStatus(ctx context.Context, ID int, status string) <-chan ResultUseCase {
+	ctxUsecase := "comment_usecase_update_status"
+	output := make(chan ResultUseCase)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := fmt.Sprintf("panic: %v", r)
+				utils.Log(log.ErrorLevel, message, ctxUsecase, "recover_usecase_update_status")
+				output <- ResultUseCase{Error: fmt.Errorf(message)}
+			}
+			close(output)
+		}()
+
+		if !shared.CallerFromContext(ctx).IsPrivileged() {
+			output <- ResultUseCase{Error: shared.ErrForbidden}
+			return
+		}
+
+		res := <-u.commentRepo.UpdateStatus(ctx, ID, status)
+		if res.Error != nil {
+			utils.Log(log.ErrorLevel, res.Error.Error(), ctxUsecase, "res_repo_update_status")
+			output <- ResultUseCase{Error: res.Error}
+			return
+		}
+
+		output <- ResultUseCase{Result: res.Result.(model.Comment)}
+	}()
+
+	return output
+}
+
+// visibleArticle function for getting article of comments, unpublished article is hidden from public
+func (u *commentUseCase) visibleArticle(ctx context.Context, articleID int) (articleModel.Article, error) {
+	res := <-u.articleRepo.GetByID(ctx, articleID, "id", "status")
+	if res.Error != nil {
+		return articleModel.Article{}, res.Error
+	}
+
+	article := res.Result.(articleModel.Article)
+	if article.Status != articleModel.StatusPublished && !shared.CallerFromContext(ctx).IsPrivileged() {
+		return article, shared.ErrDataNotFound
+	}
+
+	return article, nil
+}
+
+// visibleParams function for restricting comment params of public caller into approved comments
+func visibleParams(ctx context.Context, params model.CommentParams) model.CommentParams {
+	if !shared.CallerFromContext(ctx).IsPrivileged() {
+		params.Status = []string{model.StatusApproved}
+	}
+	return params
+}
+
+// visibleComment function for hiding email of comment and its replies from public caller
+func visibleComment(ctx context.Context, comment *model.Comment) {
+	if shared.CallerFromContext(ctx).IsPrivileged() {
+		return
+	}
+
+	comment.AuthorEmail = ""
+	for i := range comment.Replies {
+		visibleComment(ctx, &comment.Replies[i])
+	}
+}
+
+// buildThreads function for attaching replies below their parent, replies are ordered oldest first,
+// reply whose parent is not in the threads is dropped
+func buildThreads(roots, replies []model.Comment) []model.Comment {
+	children := make(map[int][]model.Comment)
+	for _, reply := range replies {
+		children[reply.ParentID] = append(children[reply.ParentID], reply)
+	}
+
+	var attach func(comment *model.Comment)
+	attach = func(comment *model.Comment) {
+		comment.Replies = children[comment.ID]
+		for i := range comment.Replies {
+			attach(&comment.Replies[i])
+		}
+	}
+
+	for i := range roots {
+		attach(&roots[i])
+	}
+
+	return roots
+}
@@ -0,0 +1,61 @@
+package usecase
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/willy182/boilerplate-go-cleanarch/src/comments/v1/model"
+)
+
+// threadIDs function for writing ids of threads with replies in parentheses, e.g. 1(2 3(4))
+func threadIDs(comments []model.Comment) string {
+	ids := make([]string, 0, len(comments))
+	for _, comment := range comments {
+		id := strconv.Itoa(comment.ID)
+		if len(comment.Replies) > 0 {
+			id += "(" + threadIDs(comment.Replies) + ")"
+		}
+		ids = append(ids, id)
+	}
+	return strings.Join(ids, " ")
+}
+
+func TestBuildThreads(t *testing.T) {
+	tests := []struct {
+		name     string
+		roots    []model.Comment
+		replies  []model.Comment
+		expected string
+	}{
+		{"no comment", nil, nil, ""},
+		{"no reply", []model.Comment{{ID: 1}, {ID: 2}}, nil, "1 2"},
+		{
+			name:     "nested replies keep their order",
+			roots:    []model.Comment{{ID: 1}, {ID: 2}},
+			replies:  []model.Comment{{ID: 3, ParentID: 1}, {ID: 4, ParentID: 3}, {ID: 5, ParentID: 2}, {ID: 7, ParentID: 1}},
+			expected: "1(3(4) 7) 2(5)",
+		},
+		{
+			name:     "reply of root on another page",
+			roots:    []model.Comment{{ID: 1}},
+			replies:  []model.Comment{{ID: 3, ParentID: 9}, {ID: 4, ParentID: 3}},
+			expected: "1",
+		},
+		{
+			// replies of hidden comment are not loaded for public caller, the rest of the thread is still shown
+			name:     "reply of hidden comment",
+			roots:    []model.Comment{{ID: 1}},
+			replies:  []model.Comment{{ID: 3, ParentID: 1}, {ID: 5, ParentID: 4}},
+			expected: "1(3)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if actual := threadIDs(buildThreads(tt.roots, tt.replies)); actual != tt.expected {
+				t.Errorf("threads = %q, expected %q", actual, tt.expected)
+			}
+		})
+	}
+}
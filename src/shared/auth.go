@@ -0,0 +1,84 @@
+package shared
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+)
+
+const (
+	// RoleAdmin role of caller that can manage all data
+	RoleAdmin = "admin"
+	// RoleEditor role of caller that can write content
+	RoleEditor = "editor"
+	// RoleReviewer role of caller that can review and publish content
+	RoleReviewer = "reviewer"
+)
+
+// Caller model of request caller, zero value means anonymous caller
+type Caller struct {
+	Name string
+	Role string
+}
+
+// callerContextKey key of caller inside context
+type callerContextKey struct{}
+
+var (
+	apiKeys     map[string]Caller
+	apiKeysOnce sync.Once
+)
+
+// loadAPIKeys function for loading api keys from API_KEYS environment,
+// the format is comma separated key:role:name, e.g. "s3cr3t:admin:willy,t0ken:editor:dina"
+func loadAPIKeys() {
+	apiKeys = make(map[string]Caller)
+	for _, entry := range strings.Split(os.Getenv("API_KEYS"), ",") {
+		parts := strings.SplitN(strings.TrimSpace(entry), ":", 3)
+		if len(parts) != 3 || parts[0] == "" {
+			continue
+		}
+		apiKeys[parts[0]] = Caller{Role: parts[1], Name: parts[2]}
+	}
+}
+
+// GetCaller function for getting request caller from Authorization bearer api key,
+// anonymous caller is returned when the key is not sent or unknown
+func GetCaller(req *http.Request) Caller {
+	apiKeysOnce.Do(loadAPIKeys)
+
+	key := strings.TrimSpace(strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer "))
+	if key == "" {
+		return Caller{}
+	}
+
+	return apiKeys[key]
+}
+
+// NewCallerContext function for storing caller inside context
+func NewCallerContext(ctx context.Context, caller Caller) context.Context {
+	return context.WithValue(ctx, callerContextKey{}, caller)
+}
+
+// CallerFromContext function for getting caller from context, anonymous caller is returned when it is not stored
+func CallerFromContext(ctx context.Context) Caller {
+	caller, _ := ctx.Value(callerContextKey{}).(Caller)
+	return caller
+}
+
+// IsAnonymous function for checking whether caller is not authenticated
+func (c Caller) IsAnonymous() bool {
+	return c.Role == ""
+}
+
+// IsAdmin function for checking whether caller has admin role
+func (c Caller) IsAdmin() bool {
+	return c.Role == RoleAdmin
+}
+
+// IsPrivileged function for checking whether caller is part of editorial team
+func (c Caller) IsPrivileged() bool {
+	return c.Role == RoleAdmin || c.Role == RoleEditor || c.Role == RoleReviewer
+}
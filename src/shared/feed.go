@@ -0,0 +1,242 @@
+package shared
+
+import (
+	"encoding/xml"
+	"mime"
+	"path"
+	"time"
+)
+
+const (
+	// RSSContentType content type of RSS 2.0 document
+	RSSContentType = "application/rss+xml; charset=utf-8"
+	// AtomContentType content type of Atom 1.0 document
+	AtomContentType = "application/atom+xml; charset=utf-8"
+)
+
+// Feed data of struct for syndication feed, every link must be absolute
+type Feed struct {
+	Title       string
+	Link        string
+	SelfLink    string
+	Description string
+	Updated     time.Time
+	Items       []FeedItem
+}
+
+// FeedItem data of struct for entry of syndication feed, ID must never change for the same entry
+type FeedItem struct {
+	ID         string
+	Title      string
+	Link       string
+	Summary    string
+	Content    string
+	Author     string
+	Categories []string
+	Image      string
+	Published  time.Time
+	Updated    time.Time
+}
+
+type (
+	rssDocument struct {
+		XMLName      xml.Name   `xml:"rss"`
+		Version      string     `xml:"version,attr"`
+		AtomNS       string     `xml:"xmlns:atom,attr"`
+		ContentNS    string     `xml:"xmlns:content,attr"`
+		DublinCoreNS string     `xml:"xmlns:dc,attr"`
+		Channel      rssChannel `xml:"channel"`
+	}
+
+	rssChannel struct {
+		Title         string    `xml:"title"`
+		Link          string    `xml:"link"`
+		AtomLink      atomLink  `xml:"atom:link"`
+		Description   string    `xml:"description"`
+		LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+		Items         []rssItem `xml:"item"`
+	}
+
+	rssItem struct {
+		Title      string        `xml:"title"`
+		Link       string        `xml:"link"`
+		GUID       rssGUID       `xml:"guid"`
+		PubDate    string        `xml:"pubDate,omitempty"`
+		Creator    string        `xml:"dc:creator,omitempty"`
+		Categories []string      `xml:"category"`
+		Enclosure  *rssEnclosure `xml:"enclosure"`
+		Summary    string        `xml:"description"`
+		Content    *cdata        `xml:"content:encoded"`
+	}
+
+	rssGUID struct {
+		IsPermaLink bool   `xml:"isPermaLink,attr"`
+		Value       string `xml:",chardata"`
+	}
+
+	rssEnclosure struct {
+		URL    string `xml:"url,attr"`
+		Type   string `xml:"type,attr"`
+		Length int    `xml:"length,attr"`
+	}
+
+	atomDocument struct {
+		XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+		Title   string      `xml:"title"`
+		ID      string      `xml:"id"`
+		Links   []atomLink  `xml:"link"`
+		Updated string      `xml:"updated"`
+		Entries []atomEntry `xml:"entry"`
+	}
+
+	atomEntry struct {
+		Title      string         `xml:"title"`
+		ID         string         `xml:"id"`
+		Links      []atomLink     `xml:"link"`
+		Published  string         `xml:"published,omitempty"`
+		Updated    string         `xml:"updated"`
+		Author     *atomAuthor    `xml:"author"`
+		Categories []atomCategory `xml:"category"`
+		Summary    *atomText      `xml:"summary"`
+		Content    *atomText      `xml:"content"`
+	}
+
+	atomLink struct {
+		Href string `xml:"href,attr"`
+		Rel  string `xml:"rel,attr,omitempty"`
+		Type string `xml:"type,attr,omitempty"`
+	}
+
+	atomAuthor struct {
+		Name string `xml:"name"`
+	}
+
+	atomCategory struct {
+		Term string `xml:"term,attr"`
+	}
+
+	atomText struct {
+		Type  string `xml:"type,attr"`
+		Value string `xml:",chardata"`
+	}
+
+	cdata struct {
+		Value string `xml:",cdata"`
+	}
+)
+
+// RSS function for encoding feed into RSS 2.0 document
+func (f *Feed) RSS() ([]byte, error) {
+	doc := rssDocument{
+		Version:      "2.0",
+		AtomNS:       "http://www.w3.org/2005/Atom",
+		ContentNS:    "http://purl.org/rss/1.0/modules/content/",
+		DublinCoreNS: "http://purl.org/dc/elements/1.1/",
+		Channel: rssChannel{
+			Title:       f.Title,
+			Link:        f.Link,
+			AtomLink:    atomLink{Href: f.SelfLink, Rel: "self", Type: "application/rss+xml"},
+			Description: f.Description,
+			Items:       make([]rssItem, 0, len(f.Items)),
+		},
+	}
+
+	if !f.Updated.IsZero() {
+		doc.Channel.LastBuildDate = f.Updated.UTC().Format(time.RFC1123Z)
+	}
+
+	for _, item := range f.Items {
+		entry := rssItem{
+			Title:      item.Title,
+			Link:       item.Link,
+			GUID:       rssGUID{IsPermaLink: item.ID == item.Link, Value: item.ID},
+			Creator:    item.Author,
+			Categories: item.Categories,
+			Summary:    item.Summary,
+		}
+
+		if !item.Published.IsZero() {
+			entry.PubDate = item.Published.UTC().Format(time.RFC1123Z)
+		}
+
+		if item.Image != "" {
+			entry.Enclosure = &rssEnclosure{URL: item.Image, Type: imageMimeType(item.Image)}
+		}
+
+		if item.Content != "" {
+			entry.Content = &cdata{Value: item.Content}
+		}
+
+		doc.Channel.Items = append(doc.Channel.Items, entry)
+	}
+
+	return encodeXML(doc)
+}
+
+// Atom function for encoding feed into Atom 1.0 document
+func (f *Feed) Atom() ([]byte, error) {
+	doc := atomDocument{
+		Title: f.Title,
+		ID:    f.SelfLink,
+		Links: []atomLink{
+			{Href: f.Link, Rel: "alternate"},
+			{Href: f.SelfLink, Rel: "self", Type: "application/atom+xml"},
+		},
+		Updated: f.Updated.UTC().Format(time.RFC3339),
+		Entries: make([]atomEntry, 0, len(f.Items)),
+	}
+
+	for _, item := range f.Items {
+		entry := atomEntry{
+			Title:   item.Title,
+			ID:      item.ID,
+			Links:   []atomLink{{Href: item.Link, Rel: "alternate"}},
+			Updated: item.Updated.UTC().Format(time.RFC3339),
+		}
+
+		if !item.Published.IsZero() {
+			entry.Published = item.Published.UTC().Format(time.RFC3339)
+		}
+
+		if item.Author != "" {
+			entry.Author = &atomAuthor{Name: item.Author}
+		}
+
+		for _, category := range item.Categories {
+			entry.Categories = append(entry.Categories, atomCategory{Term: category})
+		}
+
+		if item.Image != "" {
+			entry.Links = append(entry.Links, atomLink{Href: item.Image, Rel: "enclosure", Type: imageMimeType(item.Image)})
+		}
+
+		if item.Summary != "" {
+			entry.Summary = &atomText{Type: "text", Value: item.Summary}
+		}
+
+		if item.Content != "" {
+			entry.Content = &atomText{Type: "html", Value: item.Content}
+		}
+
+		doc.Entries = append(doc.Entries, entry)
+	}
+
+	return encodeXML(doc)
+}
+
+// encodeXML function for encoding document with xml declaration
+func encodeXML(doc interface{}) ([]byte, error) {
+	body, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
+
+// imageMimeType function for guessing mime type of image url from its extension
+func imageMimeType(url string) string {
+	if mimeType := mime.TypeByExtension(path.Ext(url)); mimeType != "" {
+		return mimeType
+	}
+	return "image/jpeg"
+}
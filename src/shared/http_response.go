@@ -32,6 +32,13 @@ type (
 		TotalRecords int `json:"totalRecords"`
 		TotalPages   int `json:"totalPages"`
 	}
+
+	// CursorMeta model
+	CursorMeta struct {
+		Limit int    `json:"limit"`
+		Next  string `json:"next,omitempty"`
+		Prev  string `json:"prev,omitempty"`
+	}
 )
 
 // NewHTTPResponse for create common response, data must in first params and meta in second params
@@ -49,6 +56,8 @@ func NewHTTPResponse(code int, message string, params ...interface{}) HTTPRespon
 		switch val := param.(type) {
 		case Meta:
 			commonResponse.Meta = val
+		case CursorMeta:
+			commonResponse.Meta = val
 		case MultiError:
 			commonResponse.Errors = val.ToMap()
 		default:
@@ -105,3 +114,12 @@ func CreateMeta(total int, page int, limit int) Meta {
 
 	return meta
 }
+
+// CreateCursorMeta method to create cursor meta response
+func CreateCursorMeta(limit int, next, prev string) CursorMeta {
+	return CursorMeta{
+		Limit: limit,
+		Next:  next,
+		Prev:  prev,
+	}
+}
@@ -0,0 +1,40 @@
+package shared
+
+import (
+	"bytes"
+	"image"
+	"image/jpeg"
+	"image/png"
+
+	"golang.org/x/image/draw"
+)
+
+// ImageJPEGQuality quality of encoded jpeg image
+const ImageJPEGQuality = 85
+
+// ResizeImage function for scaling image down into width, the aspect ratio is kept
+func ResizeImage(src image.Image, width int) image.Image {
+	bounds := src.Bounds()
+	height := bounds.Dy() * width / bounds.Dx()
+	if height < 1 {
+		height = 1
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, bounds, draw.Over, nil)
+	return dst
+}
+
+// EncodeImage function for encoding image as jpeg when isJPEG is true, otherwise as png to keep its transparency
+func EncodeImage(img image.Image, isJPEG bool) ([]byte, error) {
+	var buf bytes.Buffer
+
+	var err error
+	if isJPEG {
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: ImageJPEGQuality})
+	} else {
+		err = png.Encode(&buf, img)
+	}
+
+	return buf.Bytes(), err
+}
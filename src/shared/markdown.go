@@ -0,0 +1,135 @@
+package shared
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	"golang.org/x/net/html"
+)
+
+// markdown renderer of commonmark with strikethrough, raw html inside the source is omitted
+var markdown = goldmark.New(goldmark.WithExtensions(extension.Strikethrough))
+
+// sanitizePolicy allowlist of html tag, attribute and url scheme kept by SanitizeHTML,
+// link is marked nofollow noreferrer
+var sanitizePolicy = func() *bluemonday.Policy {
+	policy := bluemonday.NewPolicy()
+	policy.AllowElements("p", "br", "hr", "pre", "blockquote", "h1", "h2", "h3", "h4", "h5", "h6",
+		"strong", "em", "del", "ul", "li", "ol", "code")
+	policy.AllowAttrs("start").Matching(regexp.MustCompile(`^\d+$`)).OnElements("ol")
+	policy.AllowAttrs("class").Matching(regexp.MustCompile(`^language-[\w+#-]+$`)).OnElements("code")
+	policy.AllowAttrs("href", "title").OnElements("a")
+	policy.AllowAttrs("src", "alt", "title").OnElements("img")
+	policy.AllowURLSchemes("http", "https", "mailto")
+	policy.AllowRelativeURLs(true)
+	policy.RequireParseableURLs(true)
+	policy.RequireNoFollowOnLinks(true)
+	policy.RequireNoReferrerOnLinks(true)
+	return policy
+}()
+
+// textSpaces run of whitespace collapsed by HTMLToText
+var textSpaces = regexp.MustCompile(`\s+`)
+
+// textParagraphTags html tag that is separated from other text by blank line
+var textParagraphTags = map[string]bool{
+	"p": true, "hr": true, "pre": true, "blockquote": true, "div": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+}
+
+// textLineTags html tag that is separated from other text by line break
+var textLineTags = map[string]bool{"br": true, "ul": true, "ol": true, "li": true}
+
+// RenderMarkdown function for rendering markdown source into html, raw html inside the source is omitted
+// and the result is sanitized by SanitizeHTML so it is safe to be embedded by any consumer
+func RenderMarkdown(source string) string {
+	if strings.TrimSpace(source) == "" {
+		return ""
+	}
+
+	var buf bytes.Buffer
+	if err := markdown.Convert([]byte(source), &buf); err != nil {
+		return ""
+	}
+	return SanitizeHTML(buf.String())
+}
+
+// SanitizeHTML function for removing every tag, attribute and url scheme outside of the allowlist,
+// content of dangerous tag such as script is removed together with the tag
+func SanitizeHTML(source string) string {
+	return sanitizePolicy.Sanitize(source)
+}
+
+// HTMLToText function for converting html into plain text, whitespace outside of pre is collapsed like browser does,
+// paragraph is separated by blank line and list item is prefixed by dash
+func HTMLToText(source string) string {
+	tokenizer := html.NewTokenizer(strings.NewReader(source))
+
+	var (
+		buf    bytes.Buffer
+		pre    int
+		bullet bool
+	)
+
+	// breakLine ensure the text ends with count line breaks, trailing spaces are removed
+	breakLine := func(count int) {
+		if bullet {
+			return
+		}
+
+		buf.Truncate(len(bytes.TrimRight(buf.Bytes(), " ")))
+		if buf.Len() == 0 {
+			return
+		}
+
+		trimmed := bytes.TrimRight(buf.Bytes(), "\n")
+		for n := buf.Len() - len(trimmed); n < count; n++ {
+			buf.WriteByte('\n')
+		}
+	}
+
+	for {
+		tokenType := tokenizer.Next()
+		switch tokenType {
+		case html.ErrorToken:
+			return strings.TrimSpace(buf.String())
+		case html.TextToken:
+			text := string(tokenizer.Text())
+			if pre == 0 {
+				text = textSpaces.ReplaceAllString(text, " ")
+				if buf.Len() == 0 || bullet || bytes.HasSuffix(buf.Bytes(), []byte("\n")) {
+					text = strings.TrimLeft(text, " ")
+				}
+			}
+
+			if text != "" {
+				bullet = false
+				buf.WriteString(text)
+			}
+		case html.StartTagToken, html.SelfClosingTagToken, html.EndTagToken:
+			name, _ := tokenizer.TagName()
+			tag := string(name)
+
+			switch {
+			case tag == "li" && tokenType == html.StartTagToken:
+				breakLine(1)
+				buf.WriteString("- ")
+				bullet = true
+			case textLineTags[tag]:
+				breakLine(1)
+			case textParagraphTags[tag]:
+				breakLine(2)
+			}
+
+			if tag == "pre" && tokenType == html.StartTagToken {
+				pre++
+			} else if tag == "pre" && tokenType == html.EndTagToken && pre > 0 {
+				pre--
+			}
+		}
+	}
+}
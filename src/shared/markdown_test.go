@@ -0,0 +1,95 @@
+package shared
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderMarkdown(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   string
+		expected string
+	}{
+		{"empty", "  \n\t", ""},
+		{"paragraph", "hello *world*", "<p>hello <em>world</em></p>\n"},
+		{"heading", "## Title", "<h2>Title</h2>\n"},
+		{"strikethrough", "~~old~~ **new**", "<p><del>old</del> <strong>new</strong></p>\n"},
+		{"list", "1. one\n2. two", "<ol>\n<li>one</li>\n<li>two</li>\n</ol>\n"},
+		{"ordered list start", "3. three", "<ol start=\"3\">\n<li>three</li>\n</ol>\n"},
+		{"fenced code", "```go\nx := 1 < 2\n```", "<pre><code class=\"language-go\">x := 1 &lt; 2\n</code></pre>\n"},
+		{"link", "[site](https://example.com \"home\")",
+			"<p><a href=\"https://example.com\" title=\"home\" rel=\"nofollow noreferrer\">site</a></p>\n"},
+		{"relative link", "[about](/about)", "<p><a href=\"/about\" rel=\"nofollow noreferrer\">about</a></p>\n"},
+		{"image", "![cat](/cat.png)", "<p><img src=\"/cat.png\" alt=\"cat\"></p>\n"},
+		{"javascript link", "[x](javascript:alert(1))", "<p>x</p>\n"},
+		{"raw html", "<script>alert(1)</script>\n\ntext <b onclick=\"x()\">bold</b>", "\n<p>text bold</p>\n"},
+		{"unsafe code class", "```x\" onclick=\"y\ncode\n```", "<pre><code>code\n</code></pre>\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if actual := RenderMarkdown(tt.source); actual != tt.expected {
+				t.Errorf("RenderMarkdown(%q) = %q, expected %q", tt.source, actual, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRenderMarkdownLargeInput(t *testing.T) {
+	// nested emphasis and brackets are the inputs that made the previous parser quadratic
+	source := strings.Repeat("*[", 25000)
+	if actual := RenderMarkdown(source); !strings.HasPrefix(actual, "<p>") {
+		t.Errorf("RenderMarkdown of large input = %.50q", actual)
+	}
+}
+
+func TestSanitizeHTML(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   string
+		expected string
+	}{
+		{"allowed", "<p><strong>a</strong> <em>b</em></p>", "<p><strong>a</strong> <em>b</em></p>"},
+		{"unknown tag", "<div><span>text</span></div>", "text"},
+		{"script content", "a<script>alert(1)</script>b", "ab"},
+		{"style content", "<style>p{}</style>text", "text"},
+		{"iframe", `<iframe src="https://example.com"></iframe>text`, "text"},
+		{"event attribute", `<p onclick="x()">text</p>`, "<p>text</p>"},
+		{"javascript href", `<a href="javascript:alert(1)">x</a>`, "x"},
+		{"data src", `<img src="data:image/png;base64,AAAA" alt="x">`, `<img alt="x">`},
+		{"mailto href", `<a href="mailto:me@example.com">me</a>`, `<a href="mailto:me@example.com" rel="nofollow noreferrer">me</a>`},
+		{"invalid start", `<ol start="x"><li>a</li></ol>`, "<ol><li>a</li></ol>"},
+		{"comment", "a<!-- hidden -->b", "ab"},
+		{"escaped text", "1 &lt; 2 &amp; 3", "1 &lt; 2 &amp; 3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if actual := SanitizeHTML(tt.source); actual != tt.expected {
+				t.Errorf("SanitizeHTML(%q) = %q, expected %q", tt.source, actual, tt.expected)
+			}
+		})
+	}
+}
+
+func TestHTMLToText(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   string
+		expected string
+	}{
+		{"paragraphs", "<p>one\n  two</p><p>three</p>", "one two\n\nthree"},
+		{"list", "<ul><li>a</li><li>b</li></ul>", "- a\n- b"},
+		{"pre", "<pre>a\n  b</pre>", "a\n  b"},
+		{"entity", "<p>1 &lt; 2</p>", "1 < 2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if actual := HTMLToText(tt.source); actual != tt.expected {
+				t.Errorf("HTMLToText(%q) = %q, expected %q", tt.source, actual, tt.expected)
+			}
+		})
+	}
+}
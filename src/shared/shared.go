@@ -20,6 +20,10 @@ const (
 	CHARS = "abcdefghijklmnopqrstuvwxyz0123456789"
 	// NUMBERS for setting short random number
 	NUMBERS = "0123456789"
+	// LimitDefault default limit for pagination
+	LimitDefault = 10
+	// LimitMax maximum limit for pagination
+	LimitMax = 100
 
 	// this block is for validating URL format
 	email        string = "^(((([a-zA-Z]|\\d|[!#\\$%&'\\*\\+\\-\\/=\\?\\^_`{\\|}~]|[\\x{00A0}-\\x{D7FF}\\x{F900}-\\x{FDCF}\\x{FDF0}-\\x{FFEF}])+(\\.([a-zA-Z]|\\d|[!#\\$%&'\\*\\+\\-\\/=\\?\\^_`{\\|}~]|[\\x{00A0}-\\x{D7FF}\\x{F900}-\\x{FDCF}\\x{FDF0}-\\x{FFEF}])+)*)|((\\x22)((((\\x20|\\x09)*(\\x0d\\x0a))?(\\x20|\\x09)+)?(([\\x01-\\x08\\x0b\\x0c\\x0e-\\x1f\\x7f]|\\x21|[\\x23-\\x5b]|[\\x5d-\\x7e]|[\\x{00A0}-\\x{D7FF}\\x{F900}-\\x{FDCF}\\x{FDF0}-\\x{FFEF}])|(\\([\\x01-\\x09\\x0b\\x0c\\x0d-\\x7f]|[\\x{00A0}-\\x{D7FF}\\x{F900}-\\x{FDCF}\\x{FDF0}-\\x{FFEF}]))))*(((\\x20|\\x09)*(\\x0d\\x0a))?(\\x20|\\x09)+)?(\\x22)))@((([a-zA-Z]|\\d|[\\x{00A0}-\\x{D7FF}\\x{F900}-\\x{FDCF}\\x{FDF0}-\\x{FFEF}])|(([a-zA-Z]|\\d|[\\x{00A0}-\\x{D7FF}\\x{F900}-\\x{FDCF}\\x{FDF0}-\\x{FFEF}])([a-zA-Z]|\\d|-|\\.|_|~|[\\x{00A0}-\\x{D7FF}\\x{F900}-\\x{FDCF}\\x{FDF0}-\\x{FFEF}])*([a-zA-Z]|\\d|[\\x{00A0}-\\x{D7FF}\\x{F900}-\\x{FDCF}\\x{FDF0}-\\x{FFEF}])))\\.)+(([a-zA-Z]|[\\x{00A0}-\\x{D7FF}\\x{F900}-\\x{FDCF}\\x{FDF0}-\\x{FFEF}])|(([a-zA-Z]|[\\x{00A0}-\\x{D7FF}\\x{F900}-\\x{FDCF}\\x{FDF0}-\\x{FFEF}])([a-zA-Z]|\\d|-|_|~|[\\x{00A0}-\\x{D7FF}\\x{F900}-\\x{FDCF}\\x{FDF0}-\\x{FFEF}])*([a-zA-Z]|[\\x{00A0}-\\x{D7FF}\\x{F900}-\\x{FDCF}\\x{FDF0}-\\x{FFEF}])))\\.?$"
@@ -34,6 +38,10 @@ const (
 	area         string = `^\+\d{1,5}$`
 	phone        string = `^\d{5,}$`
 	alphaNumeric string = `^[A-Za-z0-9][A-Za-z0-9 ._-]*$`
+	slug         string = `^[a-z0-9]+(-[a-z0-9]+)*$`
+
+	// SlugMaxLength maximum length of generated slug
+	SlugMaxLength = 140
 )
 
 var (
@@ -43,6 +51,14 @@ var (
 	ErrBadFormatMail = errors.New("invalid email format")
 	// ErrBadFormatPhoneNumber variable for error of email format
 	ErrBadFormatPhoneNumber = errors.New("invalid phone format")
+	// ErrBadFormatSlug variable for error of slug format
+	ErrBadFormatSlug = errors.New("invalid slug format, use lowercase alphanumeric separated by dash")
+	// ErrDataNotFound variable for error when data doesn't exist
+	ErrDataNotFound = errors.New(ErrorDataNotFound)
+	// ErrDataConflict variable for error when data has been changed since it was read
+	ErrDataConflict = errors.New("data has been modified, please reload and try again")
+	// ErrForbidden variable for error when caller is not allowed to do the action
+	ErrForbidden = errors.New("forbidden")
 
 	// emailRegexp regex for validate email
 	emailRegexp = regexp.MustCompile(email)
@@ -54,6 +70,10 @@ var (
 	phoneRegexp = regexp.MustCompile(phone)
 	// alphaNumericRegexp regex for validate uri
 	alphaNumericRegexp = regexp.MustCompile(alphaNumeric)
+	// slugRegexp regex for validate slug
+	slugRegexp = regexp.MustCompile(slug)
+	// nonSlugRegexp regex for character that is not allowed in slug
+	nonSlugRegexp = regexp.MustCompile(`[^a-z0-9]+`)
 )
 
 // ValidateEmail function for validating email
@@ -200,6 +220,23 @@ func ValidateAlphanumericWithSpace(str string, must bool) bool {
 	return (uppercase >= 1 || lowercase >= 1 || num >= 1) || space >= 1
 }
 
+// ValidateSlug function for validating slug
+func ValidateSlug(str string) error {
+	if !slugRegexp.MatchString(str) {
+		return ErrBadFormatSlug
+	}
+	return nil
+}
+
+// Slugify function for converting text into url friendly slug
+func Slugify(str string) string {
+	result := strings.Trim(nonSlugRegexp.ReplaceAllString(strings.ToLower(str), "-"), "-")
+	if len(result) > SlugMaxLength {
+		result = strings.TrimRight(result[:SlugMaxLength], "-")
+	}
+	return result
+}
+
 // GenerateRandomID function for generating shipping ID
 func GenerateRandomID(length int, prefix ...string) string {
 	var strPrefix string
@@ -277,12 +314,44 @@ func GetProtocol(isTLS bool) string {
 	return "http://"
 }
 
-// GetHostURL function for getting host of any URL
+// GetHostURL function for getting host of any URL,
+// protocol forwarded by TLS terminating proxy takes precedence over the protocol of the connection
 func GetHostURL(req *http.Request) string {
-	return fmt.Sprintf("%s%s", GetProtocol(req.TLS != nil), req.Host)
+	protocol := GetProtocol(req.TLS != nil)
+	if proto := req.Header.Get("X-Forwarded-Proto"); proto == "http" || proto == "https" {
+		protocol = proto + "://"
+	}
+	return fmt.Sprintf("%s%s", protocol, req.Host)
 }
 
 // GetSelfLink function to get self link
 func GetSelfLink(req *http.Request) string {
 	return fmt.Sprintf("%s%s", GetHostURL(req), req.RequestURI)
 }
+
+// ParsePagination function for parsing page and limit query params, invalid value is appended into multiError
+func ParsePagination(pageNumber, pageSize string, multiError *MultiError) (int, int) {
+	page, limit := 1, LimitDefault
+
+	if pageNumber != "" && pageNumber != "0" {
+		if !ValidateNumeric(pageNumber) {
+			multiError.Append("page", fmt.Errorf("page must be numeric"))
+		} else {
+			page, _ = strconv.Atoi(pageNumber)
+		}
+	}
+
+	if pageSize != "" && pageSize != "0" {
+		if !ValidateNumeric(pageSize) {
+			multiError.Append("limit", fmt.Errorf("limit must be numeric"))
+		} else {
+			limit, _ = strconv.Atoi(pageSize)
+		}
+
+		if limit > LimitMax {
+			multiError.Append("limit", fmt.Errorf("limit must not be greater than %d", LimitMax))
+		}
+	}
+
+	return page, limit
+}
@@ -0,0 +1,60 @@
+package shared
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+// SitemapContentType content type of sitemap and sitemap index document
+const SitemapContentType = "application/xml; charset=utf-8"
+
+// sitemapNamespace xml namespace of sitemaps.org protocol
+const sitemapNamespace = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+// SitemapURL data of struct for location in sitemap or sitemap in sitemap index, Loc must be absolute
+type SitemapURL struct {
+	Loc          string
+	LastModified time.Time
+}
+
+type (
+	sitemapURLSet struct {
+		XMLName xml.Name          `xml:"urlset"`
+		XMLNS   string            `xml:"xmlns,attr"`
+		URLs    []sitemapLocation `xml:"url"`
+	}
+
+	sitemapIndex struct {
+		XMLName  xml.Name          `xml:"sitemapindex"`
+		XMLNS    string            `xml:"xmlns,attr"`
+		Sitemaps []sitemapLocation `xml:"sitemap"`
+	}
+
+	sitemapLocation struct {
+		Loc     string `xml:"loc"`
+		LastMod string `xml:"lastmod,omitempty"`
+	}
+)
+
+// EncodeSitemap function for encoding urls into sitemap document
+func EncodeSitemap(urls []SitemapURL) ([]byte, error) {
+	return encodeXML(sitemapURLSet{XMLNS: sitemapNamespace, URLs: sitemapLocations(urls)})
+}
+
+// EncodeSitemapIndex function for encoding urls of sitemaps into sitemap index document
+func EncodeSitemapIndex(sitemaps []SitemapURL) ([]byte, error) {
+	return encodeXML(sitemapIndex{XMLNS: sitemapNamespace, Sitemaps: sitemapLocations(sitemaps)})
+}
+
+// sitemapLocations function for converting urls into sitemap locations with W3C datetime lastmod
+func sitemapLocations(urls []SitemapURL) []sitemapLocation {
+	locations := make([]sitemapLocation, 0, len(urls))
+	for _, url := range urls {
+		location := sitemapLocation{Loc: url.Loc}
+		if !url.LastModified.IsZero() {
+			location.LastMod = url.LastModified.UTC().Format(time.RFC3339)
+		}
+		locations = append(locations, location)
+	}
+	return locations
+}